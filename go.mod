@@ -3,12 +3,11 @@ module github.com/AkuPython/Chirpy
 go 1.23.4
 
 require (
+	github.com/golang-jwt/jwt/v5 v5.2.2
 	github.com/google/uuid v1.6.0
 	github.com/joho/godotenv v1.5.1
 	github.com/lib/pq v1.10.9
-)
-
-require (
-	github.com/golang-jwt/jwt/v5 v5.2.2 // indirect
-	golang.org/x/crypto v0.38.0 // indirect
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/crypto v0.38.0
+	golang.org/x/image v0.25.0
 )
@@ -1,22 +1,39 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"sort"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/AkuPython/Chirpy/internal/auth"
+	"github.com/AkuPython/Chirpy/internal/chirptext"
 	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/AkuPython/Chirpy/internal/pagination"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 
 type userCreateParameters struct {
 	Password string `json:"password"`
 	Email string `json:"email"`
+	Handle string `json:"handle"`
+	DisplayName *string `json:"display_name"`
+	Bio *string `json:"bio"`
+	AvatarUrl *string `json:"avatar_url"`
+}
+
+// userUpdateParameters is the body of PUT /api/users, which replaces the
+// login credentials and so has to be confirmed like PATCH.
+type userUpdateParameters struct {
+	userCreateParameters
+	CurrentPassword string `json:"current_password"`
 }
 
 type errorParameters struct {
@@ -26,6 +43,13 @@ type errorParameters struct {
 type chirpParameters struct {
 	Body string `json:"body"`
 	UserId uuid.UUID `json:"user_id"`
+	ParentId *uuid.UUID `json:"parent_id"`
+	RechirpOfId *uuid.UUID `json:"rechirp_of_id"`
+	QuotedChirpId *uuid.UUID `json:"quoted_chirp_id"`
+	MediaIds []uuid.UUID `json:"media_ids"`
+	PublishAt *time.Time `json:"publish_at"`
+	Poll *pollParameters `json:"poll"`
+	Visibility string `json:"visibility"`
 }
 
 type cleanChirpParameters struct {
@@ -40,6 +64,21 @@ type tokenParameters struct {
 	Body string `json:"token"`
 }
 
+type userPatchParameters struct {
+	Email *string `json:"email"`
+	Password *string `json:"password"`
+	CurrentPassword string `json:"current_password"`
+	Handle *string `json:"handle"`
+	DisplayName *string `json:"display_name"`
+	Bio *string `json:"bio"`
+	AvatarUrl *string `json:"avatar_url"`
+	IsPrivate *bool `json:"is_private"`
+}
+
+type deletionParameters struct {
+	DeleteAfter time.Time `json:"delete_after"`
+}
+
 type polkaParameters struct {
 	Event string `json:"event"`
 	Data  struct {
@@ -52,9 +91,16 @@ type userParameters struct {
 	Created time.Time `json:"created_at"`
 	Updated time.Time `json:"updated_at"`
 	Email string `json:"email"`
+	Handle string `json:"handle,omitempty"`
+	DisplayName string `json:"display_name"`
+	Bio string `json:"bio"`
+	AvatarUrl string `json:"avatar_url"`
 	Token string `json:"token,omitempty"`
 	RefreshToken string `json:"refresh_token,omitempty"`
 	IsRed bool `json:"is_chirpy_red"`
+	IsPrivate bool `json:"is_private"`
+	FollowerCount int64 `json:"follower_count"`
+	FollowingCount int64 `json:"following_count"`
 }
 
 type Chirp struct {
@@ -63,6 +109,49 @@ type Chirp struct {
 	Updated time.Time `json:"updated_at"`
 	Body string `json:"body"`
 	UserId uuid.UUID `json:"user_id"`
+	ParentId *uuid.UUID `json:"parent_id"`
+	ReplyCount int64 `json:"reply_count"`
+	LikeCount int64 `json:"like_count"`
+	LikedByMe bool `json:"liked_by_me"`
+	Tombstone bool `json:"tombstone,omitempty"`
+	RechirpOfId *uuid.UUID `json:"rechirp_of_id,omitempty"`
+	RechirpOf *chirpSummary `json:"rechirp_of,omitempty"`
+	QuotedChirpId *uuid.UUID `json:"quoted_chirp_id,omitempty"`
+	QuotedChirp *chirpSummary `json:"quoted_chirp,omitempty"`
+	Media []chirpMedia `json:"media,omitempty"`
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Poll *chirpPoll `json:"poll,omitempty"`
+	Pinned bool `json:"pinned,omitempty"`
+	Visibility string `json:"visibility"`
+}
+
+// chirpSummary is the embedded form of a rechirped or quoted chirp. When the
+// original is gone only Id and Unavailable are set.
+type chirpSummary struct {
+	Id uuid.UUID `json:"id"`
+	Created *time.Time `json:"created_at,omitempty"`
+	Body string `json:"body,omitempty"`
+	UserId *uuid.UUID `json:"user_id,omitempty"`
+	Unavailable bool `json:"unavailable,omitempty"`
+}
+
+type chirpThreadNode struct {
+	Chirp
+	Depth int32 `json:"depth"`
+	Replies []*chirpThreadNode `json:"replies"`
+}
+
+type chirpRevision struct {
+	Id uuid.UUID `json:"id"`
+	Created time.Time `json:"created_at"`
+	ChirpId uuid.UUID `json:"chirp_id"`
+	Body string `json:"body"`
+}
+
+type chirpPage struct {
+	Chirps []Chirp `json:"chirps"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 
@@ -73,21 +162,218 @@ func convertDbChirp (dbChirp database.Chirp) Chirp {
 		Updated: dbChirp.UpdatedAt,
 		Body: dbChirp.Body,
 		UserId: dbChirp.UserID,
+		Tombstone: dbChirp.TombstonedAt.Valid,
+		Visibility: string(dbChirp.Visibility),
+	}
+	if dbChirp.ParentID.Valid {
+		parentId := dbChirp.ParentID.UUID
+		jsonChirp.ParentId = &parentId
+	}
+	if dbChirp.RechirpOfID.Valid {
+		rechirpOfId := dbChirp.RechirpOfID.UUID
+		jsonChirp.RechirpOfId = &rechirpOfId
+	}
+	if dbChirp.QuotedChirpID.Valid {
+		quotedChirpId := dbChirp.QuotedChirpID.UUID
+		jsonChirp.QuotedChirpId = &quotedChirpId
+	}
+	if dbChirp.PublishAt.Valid {
+		publishAt := dbChirp.PublishAt.Time
+		jsonChirp.PublishAt = &publishAt
+	}
+	if dbChirp.DeletedAt.Valid {
+		deletedAt := dbChirp.DeletedAt.Time
+		jsonChirp.DeletedAt = &deletedAt
 	}
 	return jsonChirp
 }
 
-func convertDbUser (userDB database.User) userParameters {
+// tombstoneChirp keeps only what a tombstone needs to hold its place in a
+// thread; who wrote it and what it pointed at are gone with the body.
+func tombstoneChirp (chirp Chirp) Chirp {
+	return Chirp{
+		Id: chirp.Id,
+		Created: chirp.Created,
+		Updated: chirp.Updated,
+		ParentId: chirp.ParentId,
+		ReplyCount: chirp.ReplyCount,
+		Tombstone: true,
+		Visibility: chirp.Visibility,
+	}
+}
+
+func newChirpSummary (id uuid.UUID, referenced map[uuid.UUID]database.Chirp) *chirpSummary {
+	original, ok := referenced[id]
+	if !ok {
+		return &chirpSummary{Id: id, Unavailable: true}
+	}
+	return &chirpSummary{
+		Id: original.ID,
+		Created: &original.CreatedAt,
+		Body: original.Body,
+		UserId: &original.UserID,
+	}
+}
+
+// convertDbChirps converts chirps and fills in the counts that live in other
+// rows, batching the lookups so a page costs one extra query per count.
+// viewer is uuid.Nil for anonymous requests.
+func (cfg *apiConfig) convertDbChirps (ctx context.Context, viewer uuid.UUID, dbChirps []database.Chirp) ([]Chirp, error) {
+	jsonChirps := []Chirp{}
+	if len(dbChirps) == 0 {
+		return jsonChirps, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(dbChirps))
+	for _, chirp := range dbChirps {
+		ids = append(ids, chirp.ID)
+	}
+	replyCounts, err := cfg.db.ChirpReplyCounts(ctx, database.ChirpReplyCountsParams{ChirpIds: ids, ViewerID: viewer})
+	if err != nil {
+		return nil, err
+	}
+	replies := make(map[uuid.UUID]int64)
+	for _, rc := range replyCounts {
+		replies[rc.ChirpID] = rc.ReplyCount
+	}
+
+	likeCounts, err := cfg.db.ChirpLikeCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	likes := make(map[uuid.UUID]int64)
+	for _, lc := range likeCounts {
+		likes[lc.ChirpID] = lc.LikeCount
+	}
+
+	likedByMe := make(map[uuid.UUID]bool)
+	if viewer != uuid.Nil {
+		liked, err := cfg.db.ChirpsLikedBy(ctx, database.ChirpsLikedByParams{UserID: viewer, ChirpIds: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, chirpId := range liked {
+			likedByMe[chirpId] = true
+		}
+	}
+
+	dbMedia, err := cfg.db.MediaForChirps(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	attached := make(map[uuid.UUID][]chirpMedia)
+	for _, m := range dbMedia {
+		attached[m.ChirpID.UUID] = append(attached[m.ChirpID.UUID], cfg.newChirpMedia(m))
+	}
+
+	polls, err := cfg.loadPolls(ctx, viewer, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var referencedIds []uuid.UUID
+	for _, chirp := range dbChirps {
+		if chirp.RechirpOfID.Valid {
+			referencedIds = append(referencedIds, chirp.RechirpOfID.UUID)
+		}
+		if chirp.QuotedChirpID.Valid {
+			referencedIds = append(referencedIds, chirp.QuotedChirpID.UUID)
+		}
+	}
+	referenced := make(map[uuid.UUID]database.Chirp)
+	if len(referencedIds) > 0 {
+		originals, err := cfg.db.ChirpsGetByIDs(ctx, database.ChirpsGetByIDsParams{Ids: referencedIds, ViewerID: viewer})
+		if err != nil {
+			return nil, err
+		}
+		for _, original := range originals {
+			referenced[original.ID] = original
+		}
+	}
+
+	for _, chirp := range dbChirps {
+		jsonChirp := convertDbChirp(chirp)
+		jsonChirp.ReplyCount = replies[chirp.ID]
+		jsonChirp.LikeCount = likes[chirp.ID]
+		jsonChirp.LikedByMe = likedByMe[chirp.ID]
+		if !chirp.TombstonedAt.Valid {
+			jsonChirp.Media = attached[chirp.ID]
+			jsonChirp.Poll = polls[chirp.ID]
+		}
+		if chirp.RechirpOfID.Valid {
+			jsonChirp.RechirpOf = newChirpSummary(chirp.RechirpOfID.UUID, referenced)
+		}
+		if chirp.QuotedChirpID.Valid {
+			jsonChirp.QuotedChirp = newChirpSummary(chirp.QuotedChirpID.UUID, referenced)
+		}
+		jsonChirps = append(jsonChirps, jsonChirp)
+	}
+	return jsonChirps, nil
+}
+
+func (cfg *apiConfig) convertOneDbChirp (ctx context.Context, viewer uuid.UUID, dbChirp database.Chirp) (Chirp, error) {
+	jsonChirps, err := cfg.convertDbChirps(ctx, viewer, []database.Chirp{dbChirp})
+	if err != nil {
+		return Chirp{}, err
+	}
+	return jsonChirps[0], nil
+}
+
+// newChirpPage expects one row more than limit was fetched; when that extra
+// row is present it is dropped and next_cursor points at the last kept row.
+func (cfg *apiConfig) newChirpPage (ctx context.Context, viewer uuid.UUID, dbChirps []database.Chirp, limit int32) (chirpPage, error) {
+	page := chirpPage{}
+	if len(dbChirps) > int(limit) {
+		dbChirps = dbChirps[:limit]
+		last := dbChirps[len(dbChirps)-1]
+		page.NextCursor = pagination.EncodeCursor(last.CreatedAt, last.ID)
+	}
+	jsonChirps, err := cfg.convertDbChirps(ctx, viewer, dbChirps)
+	if err != nil {
+		return chirpPage{}, err
+	}
+	page.Chirps = jsonChirps
+	return page, nil
+}
+
+func convertDbUser (userDB database.User, counts database.UserFollowCountsRow) userParameters {
 	user := userParameters{
 		Id: userDB.ID,
 		Created: userDB.CreatedAt,
 		Updated: userDB.UpdatedAt,
 		Email: userDB.Email,
+		Handle: userDB.Handle.String,
+		DisplayName: userDB.DisplayName.String,
+		Bio: userDB.Bio.String,
+		AvatarUrl: userDB.AvatarUrl.String,
 		IsRed: userDB.IsChirpyRed,
+		IsPrivate: userDB.IsPrivate,
+		FollowerCount: counts.FollowerCount,
+		FollowingCount: counts.FollowingCount,
 	}
 	return user
 }
 
+// isUniqueViolation reports whether err is Postgres rejecting a duplicate
+// value for a UNIQUE column.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// parseHandle validates an optional handle from a request body. An empty
+// handle comes back as a NULL string so the column is left alone.
+func parseHandle(h string) (sql.NullString, error) {
+	if h == "" {
+		return sql.NullString{}, nil
+	}
+	handle, err := chirptext.NormalizeHandle(h)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: handle, Valid: true}, nil
+}
+
 func writeJSON(w http.ResponseWriter, c int, resp any) {
 	dat, err := json.Marshal(resp)
 	if err != nil {
@@ -100,6 +386,36 @@ func writeJSON(w http.ResponseWriter, c int, resp any) {
 	return
 }
 
+// getTokenUser returns the user from the request's bearer token. When the
+// token is missing or invalid a 401 has already been written.
+func (cfg *apiConfig) getTokenUser(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	token, err := auth.GetBearerToken(r.Header)
+	if err != nil {
+		writeJSON(w, 401, errorParameters{Body: "No Auth Header in request"})
+		return uuid.Nil, false
+	}
+	token_user, err := auth.ValidateJWT(token, cfg.jwt_secret)
+	if err != nil {
+		writeJSON(w, 401, errorParameters{Body: "Invalid or expired token"})
+		return uuid.Nil, false
+	}
+	return token_user, true
+}
+
+// getViewer returns the user behind an optional bearer token, or uuid.Nil
+// for anonymous requests and tokens that do not validate.
+func (cfg *apiConfig) getViewer(r *http.Request) uuid.UUID {
+	token, err := auth.GetBearerToken(r.Header)
+	if err != nil {
+		return uuid.Nil
+	}
+	token_user, err := auth.ValidateJWT(token, cfg.jwt_secret)
+	if err != nil {
+		return uuid.Nil
+	}
+	return token_user
+}
+
 func (cfg *apiConfig) middlewareMetricsInc(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		cfg.fileserverHits.Add(1)
@@ -128,6 +444,7 @@ func (cfg *apiConfig) handlerResetMetrics(w http.ResponseWriter, r *http.Request
 	cfg.fileserverHits.Store(0)
 
 	cfg.db.DeleteUsers(r.Context())
+	cfg.db.DeleteAuthorlessChirps(r.Context())
 	w.Header().Add("Content-Type", "text/plain; charset=utf-8")	
 
 	w.WriteHeader(200)
@@ -141,33 +458,120 @@ func (cfg *apiConfig) handlerGetChirp(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
 		return
 	}
-	chirp, err := cfg.db.ChirpGet(r.Context(), chirpUUID)
+	viewer := cfg.getViewer(r)
+	chirp, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: chirpUUID, ViewerID: viewer})
 	if err != nil {
 		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v\nErr: %v", chirpId, err)})
 		return
 	}
-	writeJSON(w, 200, convertDbChirp(chirp))
+	jsonChirp, err := cfg.convertOneDbChirp(r.Context(), viewer, chirp)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirp: %v", err)})
+		return
+	}
+	writeJSON(w, 200, jsonChirp)
+}
+
+// Clients from before pagination send neither limit nor cursor. They still
+// get a bare array, but only the first page of it; the next page is linked
+// from a Link header instead of the body.
+func chirpsPaged(r *http.Request) bool {
+	return r.URL.Query().Has("limit") || r.URL.Query().Has("cursor")
+}
+
+func writeChirpPage(w http.ResponseWriter, r *http.Request, resp chirpPage) {
+	if !chirpsPaged(r) {
+		if resp.NextCursor != "" {
+			next := *r.URL
+			query := next.Query()
+			query.Set("cursor", resp.NextCursor)
+			next.RawQuery = query.Encode()
+			w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.RequestURI()))
+		}
+		writeJSON(w, 200, resp.Chirps)
+		return
+	}
+	writeJSON(w, 200, resp)
 }
 
 func (cfg *apiConfig) handlerGetChirps(w http.ResponseWriter, r *http.Request) {
 	userUUID, _ := uuid.Parse(r.URL.Query().Get("author_id"))
 	sort_opt := r.URL.Query().Get("sort")
-	
-	chirps, err := cfg.db.ChirpsGet(r.Context(), userUUID)
+	viewer := cfg.getViewer(r)
+
+	page, err := pagination.ParseQuery(r.URL.Query())
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Invalid pagination: %v", err)})
+		return
+	}
+	if sort_opt == "likes" {
+		cfg.getChirpsByLikes(w, r, viewer, userUUID, page)
+		return
+	}
+
+	chirps, err := cfg.db.ChirpsGet(r.Context(), database.ChirpsGetParams{
+		AuthorID: userUUID,
+		ViewerID: viewer,
+		HasCursor: page.HasCursor,
+		SortDesc: sort_opt == "desc",
+		CursorCreatedAt: page.Cursor.CreatedAt,
+		CursorID: page.Cursor.ID,
+		RowLimit: page.Limit + 1,
+	})
 	if err != nil {
 		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
 		return
 	}
-	jsonChirps := []Chirp{}
-	for _, chirp := range chirps {
-		jsonChirps = append(jsonChirps, convertDbChirp(chirp))
+
+	resp, err := cfg.newChirpPage(r.Context(), viewer, chirps, page.Limit)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
+		return
 	}
+	if err := cfg.prependPinned(r.Context(), viewer, userUUID, page.HasCursor, &resp); err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
+		return
+	}
+	writeChirpPage(w, r, resp)
+}
 
-	if sort_opt == "desc" {
-		sort.Slice(jsonChirps, func(i, j int) bool { return chirps[i].CreatedAt.After(chirps[j].CreatedAt) })
+// getChirpsByLikes serves sort=likes, most liked first. Its cursor carries
+// the like count as well, since that is the leading sort key.
+func (cfg *apiConfig) getChirpsByLikes(w http.ResponseWriter, r *http.Request, viewer uuid.UUID, userUUID uuid.UUID, page pagination.Page) {
+	rows, err := cfg.db.ChirpsGetByLikes(r.Context(), database.ChirpsGetByLikesParams{
+		AuthorID: userUUID,
+		ViewerID: viewer,
+		HasCursor: page.HasCursor,
+		CursorLikes: page.Cursor.Score,
+		CursorCreatedAt: page.Cursor.CreatedAt,
+		CursorID: page.Cursor.ID,
+		RowLimit: page.Limit + 1,
+	})
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
+		return
 	}
-	
-	writeJSON(w, 200, jsonChirps)
+
+	resp := chirpPage{}
+	if len(rows) > int(page.Limit) {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		resp.NextCursor = pagination.EncodeScoreCursor(last.LikeCount, last.Chirp.CreatedAt, last.Chirp.ID)
+	}
+	dbChirps := make([]database.Chirp, 0, len(rows))
+	for _, row := range rows {
+		dbChirps = append(dbChirps, row.Chirp)
+	}
+	resp.Chirps, err = cfg.convertDbChirps(r.Context(), viewer, dbChirps)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
+		return
+	}
+	if err := cfg.prependPinned(r.Context(), viewer, userUUID, page.HasCursor, &resp); err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
+		return
+	}
+	writeChirpPage(w, r, resp)
 }
 
 func (cfg *apiConfig) handlerLogin(w http.ResponseWriter, r *http.Request) {
@@ -191,6 +595,15 @@ func (cfg *apiConfig) handlerLogin(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, 401, errorParameters{Body: "Invalid Password"})
 		return
 	}
+	// Logging back in during the grace period keeps the account.
+	if userDB.DeletionRequestedAt.Valid {
+		err = cfg.db.UserCancelDeletion(r.Context(), userDB.ID)
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "Could not cancel account deletion"})
+			return
+		}
+	}
+
 	expires := time.Duration(3600 * int(time.Second))
 	token, err := auth.MakeJWT(userDB.ID, cfg.jwt_secret, expires)
 	
@@ -215,7 +628,13 @@ func (cfg *apiConfig) handlerLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	returnParams := convertDbUser(userDB)
+	counts, err := cfg.db.UserFollowCounts(r.Context(), userDB.ID)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not count followers"})
+		return
+	}
+
+	returnParams := convertDbUser(userDB, counts)
 	returnParams.Token = token
 	returnParams.RefreshToken = refresh_token
 
@@ -267,6 +686,84 @@ func (cfg *apiConfig) handlerRevoke(w http.ResponseWriter, r *http.Request) {
 
 }
 
+const (
+	chirpLengthLimit    = 140
+	chirpRedLengthLimit = 280
+)
+
+// chirpLimit returns how many characters the user may put in a chirp,
+// which depends on their plan.
+func (cfg *apiConfig) chirpLimit(ctx context.Context, userId uuid.UUID) (int, error) {
+	userDB, err := cfg.db.GetUserByID(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+	if userDB.IsChirpyRed {
+		return chirpRedLengthLimit, nil
+	}
+	return chirpLengthLimit, nil
+}
+
+// cleanChirpBody runs the length and profanity checks every chirp body has to
+// pass before it is stored, and returns the cleaned body along with the
+// profanity rules that matched. Length is counted in grapheme clusters.
+func (cfg *apiConfig) cleanChirpBody(body string, limit int) (string, []string, error) {
+	if length := chirptext.Length(body); length > limit {
+		return "", nil, fmt.Errorf("Chirp is too long: %d characters, the limit is %d", length, limit)
+	}
+
+	res := cfg.profanity.Check(body)
+	if res.Rejected {
+		return "", res.Rules, fmt.Errorf("Chirp contains blocked language")
+	}
+	return res.Text, res.Rules, nil
+}
+
+// saveChirpEntities stores the hashtags and @mentions found in a chirp's
+// body, plus the profanity rules it tripped. Tags are dated by the chirp, so
+// imported chirps do not show up as trending. replace clears what an earlier
+// version of the body left behind. mentions is false for imports, whose
+// @handles named accounts elsewhere. q is taken explicitly so callers can run
+// it inside their transaction.
+func saveChirpEntities(ctx context.Context, q *database.Queries, chirp database.Chirp, rules []string, replace bool, mentions bool) error {
+	if replace {
+		if err := q.ChirpFilterHitsClear(ctx, chirp.ID); err != nil {
+			return err
+		}
+		if err := q.ChirpTagsClear(ctx, chirp.ID); err != nil {
+			return err
+		}
+		if err := q.MentionsClear(ctx, chirp.ID); err != nil {
+			return err
+		}
+	}
+	if len(rules) > 0 {
+		err := q.ChirpFilterHitsAdd(ctx, database.ChirpFilterHitsAddParams{
+			ChirpID: chirp.ID,
+			Rules: rules,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err := q.ChirpTagsAdd(ctx, database.ChirpTagsAddParams{
+		Names: chirptext.Hashtags(chirp.Body),
+		ChirpID: chirp.ID,
+		CreatedAt: chirp.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	if !mentions {
+		return nil
+	}
+	return q.MentionsAdd(ctx, database.MentionsAddParams{
+		ChirpID: chirp.ID,
+		Handles: chirptext.Mentions(chirp.Body),
+		AuthorID: chirp.UserID,
+	})
+}
+
 func (cfg *apiConfig) handlerAddChirps(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -292,44 +789,167 @@ func (cfg *apiConfig) handlerAddChirps(w http.ResponseWriter, r *http.Request) {
 
 	var resp any
 
-	bad_words := make(map[string]string)
-	bad_words["kerfuffle"] = "****"
-	bad_words["sharbert"] = "****"
-	bad_words["fornax"] = "****"
-
-
 	if err != nil {
 		resp = errorParameters{Body: "Something went wrong"}
 		writeJSON(w, 400, resp)
 		return
 	}
 
-	if len(newChirp.Body) > 140 {
-		resp = errorParameters{Body: "Chirp is too long"}
-		writeJSON(w, 400, resp)
+	if newChirp.RechirpOfId != nil && newChirp.QuotedChirpId != nil {
+		writeJSON(w, 400, errorParameters{Body: "A chirp cannot be both a rechirp and a quote"})
 		return
 	}
-	
-	var cleaned []string
-	for _, word := range(strings.Fields(newChirp.Body)) {
-		if _, ok := bad_words[strings.ToLower(word)]; ok {
-			cleaned = append(cleaned, "****")
-		} else {
-			cleaned = append(cleaned, word)
-		}
+
+	if err := checkMediaIds(newChirp.MediaIds); err != nil {
+		writeJSON(w, 400, errorParameters{Body: err.Error()})
+		return
 	}
+
 	var chirp database.ChirpAddParams
-	chirp.Body = strings.Join(cleaned, " ")
+	var rules []string
 	chirp.UserID = token_user
 
-	added_chirp, err := cfg.db.ChirpAdd(r.Context(), chirp)
+	if newChirp.PublishAt != nil {
+		publishAt, err := checkPublishAt(*newChirp.PublishAt)
+		if err != nil {
+			writeJSON(w, 400, errorParameters{Body: err.Error()})
+			return
+		}
+		chirp.PublishAt = publishAt
+	}
+
+	var pollLabels []string
+	if newChirp.Poll != nil {
+		pollLabels, err = cfg.checkPoll(*newChirp.Poll, chirp.PublishAt)
+		if err != nil {
+			writeJSON(w, 400, errorParameters{Body: err.Error()})
+			return
+		}
+	}
+
+	if newChirp.RechirpOfId != nil {
+		// A rechirp only amplifies the original, so it carries no body.
+		if strings.TrimSpace(newChirp.Body) != "" || newChirp.ParentId != nil || len(newChirp.MediaIds) > 0 || newChirp.Poll != nil {
+			writeJSON(w, 400, errorParameters{Body: "Rechirps cannot have a body, parent, attachments or poll"})
+			return
+		}
+		original, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: *newChirp.RechirpOfId, ViewerID: token_user})
+		if err != nil {
+			writeJSON(w, 400, errorParameters{Body: "Rechirped chirp not found"})
+			return
+		}
+		if !canRechirp(original) {
+			writeJSON(w, 400, errorParameters{Body: "Only public or unlisted chirps can be rechirped"})
+			return
+		}
+		originalId := original.ID
+		if original.RechirpOfID.Valid {
+			originalId = original.RechirpOfID.UUID
+		}
+		chirp.RechirpOfID = uuid.NullUUID{UUID: originalId, Valid: true}
+	} else {
+		limit, err := cfg.chirpLimit(r.Context(), token_user)
+		if err != nil {
+			writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+			return
+		}
+		cleanedBody, matched, err := cfg.cleanChirpBody(newChirp.Body, limit)
+		if err != nil {
+			resp = errorParameters{Body: err.Error()}
+			writeJSON(w, 400, resp)
+			return
+		}
+		chirp.Body = cleanedBody
+		rules = matched
+	}
+
+	chirp.Visibility, err = parseVisibility(newChirp.Visibility, chirp.Body)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: err.Error()})
+		return
+	}
+
+	if newChirp.QuotedChirpId != nil {
+		if strings.TrimSpace(chirp.Body) == "" {
+			writeJSON(w, 400, errorParameters{Body: "Quote chirps need a body"})
+			return
+		}
+		quoted, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: *newChirp.QuotedChirpId, ViewerID: token_user})
+		if err != nil {
+			writeJSON(w, 400, errorParameters{Body: "Quoted chirp not found"})
+			return
+		}
+		chirp.QuotedChirpID = uuid.NullUUID{UUID: quoted.ID, Valid: true}
+	}
+
+	if newChirp.ParentId != nil {
+		parent, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: *newChirp.ParentId, ViewerID: token_user})
+		if err != nil {
+			writeJSON(w, 400, errorParameters{Body: "Parent chirp not found"})
+			return
+		}
+		chirp.ParentID = uuid.NullUUID{UUID: parent.ID, Valid: true}
+	}
+
+	tx, err := cfg.conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Adding Chirp Failed!"})
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	added_chirp, err := qtx.ChirpAdd(r.Context(), chirp)
+	if isUniqueViolation(err) {
+		writeJSON(w, 409, errorParameters{Body: "Already rechirped"})
+		return
+	}
 	if err != nil {
 		resp = errorParameters{Body: "Adding Chirp Failed!"}
 		writeJSON(w, 400, resp)
 		return
 	}
-	jsonChirp := convertDbChirp(added_chirp)
-	
+	if len(newChirp.MediaIds) > 0 {
+		attached, err := qtx.MediaAttach(r.Context(), database.MediaAttachParams{
+			ChirpID: added_chirp.ID,
+			Ids: newChirp.MediaIds,
+			UserID: token_user,
+		})
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "Attaching Media Failed!"})
+			return
+		}
+		if attached != int64(len(newChirp.MediaIds)) {
+			writeJSON(w, 400, errorParameters{Body: "Attachments must be your own uploads and not already used"})
+			return
+		}
+	}
+	if newChirp.Poll != nil {
+		err = qtx.PollAdd(r.Context(), database.PollAddParams{
+			ChirpID: added_chirp.ID,
+			ClosesAt: newChirp.Poll.ClosesAt.UTC(),
+			Labels: pollLabels,
+		})
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "Adding Poll Failed!"})
+			return
+		}
+	}
+	err = saveChirpEntities(r.Context(), qtx, added_chirp, rules, false, true)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Saving Chirp Tags Failed!"})
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Adding Chirp Failed!"})
+		return
+	}
+	jsonChirp, err := cfg.convertOneDbChirp(r.Context(), token_user, added_chirp)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirp: %v", err)})
+		return
+	}
+
 	writeJSON(w, 201, jsonChirp)
 
 }
@@ -356,7 +976,7 @@ func (cfg *apiConfig) handlerDeleteChirps(w http.ResponseWriter, r *http.Request
 		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
 		return
 	}
-	chirp, err := cfg.db.ChirpGet(r.Context(), chirpUUID)
+	chirp, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: chirpUUID, ViewerID: token_user})
 	if err != nil {
 		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v\nErr: %v", chirpId, err)})
 		return
@@ -366,10 +986,227 @@ func (cfg *apiConfig) handlerDeleteChirps(w http.ResponseWriter, r *http.Request
 		return
 
 	}
-	cfg.db.ChirpDelete(r.Context(), chirpUUID)
+	// The chirp goes to the trash; purgeDeletedChirps removes it for good.
+	err = cfg.db.ChirpSoftDelete(r.Context(), chirpUUID)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not delete chirp"})
+		return
+	}
 	w.WriteHeader(204)
 }
 
+func (cfg *apiConfig) handlerUpdateChirp(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	newChirp := chirpParameters{}
+	err = decoder.Decode(&newChirp)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: "Something went wrong"})
+		return
+	}
+
+	chirp, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: chirpUUID, ViewerID: token_user})
+	if err != nil {
+		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+	if chirp.UserID != token_user {
+		writeJSON(w, 403, errorParameters{Body: "Wrong user for edit!"})
+		return
+	}
+	if chirp.RechirpOfID.Valid {
+		writeJSON(w, 400, errorParameters{Body: "Rechirps cannot be edited"})
+		return
+	}
+
+	limit, err := cfg.chirpLimit(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+	cleanedBody, rules, err := cfg.cleanChirpBody(newChirp.Body, limit)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: err.Error()})
+		return
+	}
+	if err := checkEditVisibility(chirp, cleanedBody); err != nil {
+		writeJSON(w, 400, errorParameters{Body: err.Error()})
+		return
+	}
+	if cleanedBody != chirp.Body {
+		tx, err := cfg.conn.BeginTx(r.Context(), nil)
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "Updating Chirp Failed!"})
+			return
+		}
+		defer tx.Rollback()
+		qtx := cfg.db.WithTx(tx)
+
+		chirp, err = qtx.ChirpUpdate(r.Context(), database.ChirpUpdateParams{ID: chirpUUID, Body: cleanedBody})
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "Updating Chirp Failed!"})
+			return
+		}
+		err = saveChirpEntities(r.Context(), qtx, chirp, rules, true, true)
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "Saving Chirp Tags Failed!"})
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			writeJSON(w, 500, errorParameters{Body: "Updating Chirp Failed!"})
+			return
+		}
+	}
+	jsonChirp, err := cfg.convertOneDbChirp(r.Context(), token_user, chirp)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirp: %v", err)})
+		return
+	}
+	writeJSON(w, 200, jsonChirp)
+}
+
+func (cfg *apiConfig) handlerGetChirpRevisions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+	if _, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: chirpUUID, ViewerID: cfg.getViewer(r)}); err != nil {
+		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+
+	revisions, err := cfg.db.ChirpRevisionsGet(r.Context(), chirpUUID)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Revisions: %v", err)})
+		return
+	}
+	jsonRevisions := []chirpRevision{}
+	for _, rev := range revisions {
+		jsonRevisions = append(jsonRevisions, chirpRevision{
+			Id: rev.ID,
+			Created: rev.CreatedAt,
+			ChirpId: rev.ChirpID,
+			Body: rev.Body,
+		})
+	}
+	writeJSON(w, 200, jsonRevisions)
+}
+
+func (cfg *apiConfig) handlerGetChirpThread(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+
+	viewer := cfg.getViewer(r)
+	rows, err := cfg.db.ChirpThread(r.Context(), database.ChirpThreadParams{ID: chirpUUID, ViewerID: viewer})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Thread: %v", err)})
+		return
+	}
+	if len(rows) == 0 {
+		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v", chirpId)})
+		return
+	}
+
+	dbChirps := make([]database.Chirp, 0, len(rows))
+	for _, row := range rows {
+		chirp := database.Chirp{
+			ID: row.ID,
+			CreatedAt: row.CreatedAt,
+			UpdatedAt: row.UpdatedAt,
+			Body: row.Body,
+			UserID: row.UserID,
+			ParentID: row.ParentID,
+			TombstonedAt: row.TombstonedAt,
+			RechirpOfID: row.RechirpOfID,
+			QuotedChirpID: row.QuotedChirpID,
+			SearchVector: row.SearchVector,
+			PublishAt: row.PublishAt,
+			Visibility: row.Visibility,
+		}
+		// Chirps in the trash stay in the thread as tombstones so their
+		// replies keep their place.
+		if row.DeletedAt.Valid {
+			chirp.Body = ""
+			chirp.TombstonedAt = row.DeletedAt
+		}
+		dbChirps = append(dbChirps, chirp)
+	}
+	jsonChirps, err := cfg.convertDbChirps(r.Context(), viewer, dbChirps)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Thread: %v", err)})
+		return
+	}
+
+	// Rows come back ordered by depth, so every parent is seen before its replies.
+	nodes := make(map[uuid.UUID]*chirpThreadNode)
+	var root *chirpThreadNode
+	for i, row := range rows {
+		// A trashed chirp cannot be the one asked for.
+		if row.ID == chirpUUID && row.DeletedAt.Valid {
+			writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v", chirpId)})
+			return
+		}
+		jsonChirp := jsonChirps[i]
+		if jsonChirp.Tombstone {
+			jsonChirp = tombstoneChirp(jsonChirp)
+		}
+		node := &chirpThreadNode{Chirp: jsonChirp, Depth: row.Depth, Replies: []*chirpThreadNode{}}
+		nodes[row.ID] = node
+		if row.Depth == 0 {
+			root = node
+			continue
+		}
+		if parent, ok := nodes[row.ParentID.UUID]; ok {
+			parent.Replies = append(parent.Replies, node)
+		}
+	}
+	// Tombstones are only there to hold replies in place. Going deepest
+	// first drops the ones left with nothing under them, including those
+	// whose replies were tombstones too.
+	for i := len(rows) - 1; i >= 0; i-- {
+		node := nodes[rows[i].ID]
+		if node == nil || !node.Tombstone || len(node.Replies) > 0 || node.Depth == 0 {
+			continue
+		}
+		delete(nodes, rows[i].ID)
+		if parent, ok := nodes[rows[i].ParentID.UUID]; ok {
+			parent.Replies = slices.DeleteFunc(parent.Replies, func(reply *chirpThreadNode) bool {
+				return reply == node
+			})
+		}
+	}
+	// A thread whose chirp or root the caller may not see is reported
+	// exactly like one that does not exist.
+	if _, ok := nodes[chirpUUID]; !ok || root == nil {
+		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v", chirpId)})
+		return
+	}
+	writeJSON(w, 200, root)
+}
+
 func handlerReadiness(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "text/plain; charset=utf-8")	
 	w.WriteHeader(200)
@@ -388,13 +1225,22 @@ func (cfg *apiConfig) handlerAddUser(w http.ResponseWriter, r *http.Request) {
 	
 	if err == nil {
 		email := user.Email
+		handle, err := parseHandle(user.Handle)
+		if err != nil {
+			writeJSON(w, 400, errorParameters{Body: err.Error()})
+			return
+		}
 		password, err := auth.HashPassword(user.Password)
 		if err != nil {
 			writeJSON(w, 500, errorParameters{Body: "PW Hash fail!"})
 			return
 		}
-		userParam := database.CreateUserParams{Email: email, HashedPassword: password}
+		userParam := database.CreateUserParams{Email: email, HashedPassword: password, Handle: handle}
 		newUser, err = cfg.db.CreateUser(r.Context(), userParam)
+		if isUniqueViolation(err) {
+			writeJSON(w, 409, errorParameters{Body: "Email or handle already taken"})
+			return
+		}
 	}
 
 	if err != nil {
@@ -407,6 +1253,7 @@ func (cfg *apiConfig) handlerAddUser(w http.ResponseWriter, r *http.Request) {
 			Created: newUser.CreatedAt,
 			Updated: newUser.UpdatedAt,
 			Email: newUser.Email,
+			Handle: newUser.Handle.String,
 			IsRed: newUser.IsChirpyRed,
 		}
 	}
@@ -423,12 +1270,17 @@ func (cfg *apiConfig) handlerUpdateUser(w http.ResponseWriter, r *http.Request)
 	w.Header().Add("Content-Type", "application/json")	
 
 	decoder := json.NewDecoder(r.Body)
-	user := userCreateParameters{}
+	user := userUpdateParameters{}
 	err := decoder.Decode(&user)
 	if err != nil {
 		writeJSON(w, 400, errorParameters{Body: "Invalid Body"})
 		return
 	}
+	// PUT replaces both, so a missing one would be blanked. PATCH is for partial updates.
+	if user.Email == "" || user.Password == "" {
+		writeJSON(w, 400, errorParameters{Body: "email and password are both required, use PATCH to change only some fields"})
+		return
+	}
 
 	token, err := auth.GetBearerToken(r.Header)
 	if err != nil {
@@ -446,20 +1298,66 @@ func (cfg *apiConfig) handlerUpdateUser(w http.ResponseWriter, r *http.Request)
 	
 	var updatedUser database.User
 
+	// Same rule as PATCH: the credentials only change with the current password.
+	userDB, err := cfg.db.GetUserByID(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+	if auth.CheckPasswordHash(userDB.HashedPassword, user.CurrentPassword) != nil {
+		writeJSON(w, 401, errorParameters{Body: "Invalid current password"})
+		return
+	}
+	passwordChanged := auth.CheckPasswordHash(userDB.HashedPassword, user.Password) != nil
+
+	handle, err := parseHandle(user.Handle)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: err.Error()})
+		return
+	}
 	password, err := auth.HashPassword(user.Password)
 	if err != nil {
 		writeJSON(w, 500, errorParameters{Body: "PW Hash fail!"})
 		return
 	}
-	updateParams := database.UpdateOneUserParams{ID: token_user, Email: user.Email, HashedPassword: password}
+	profile, err := parseProfile(user.userCreateParameters)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: err.Error()})
+		return
+	}
+	updateParams := database.UpdateOneUserParams{
+		ID: token_user,
+		Email: user.Email,
+		HashedPassword: password,
+		Handle: handle,
+		DisplayName: profile.DisplayName,
+		Bio: profile.Bio,
+		AvatarUrl: profile.AvatarUrl,
+	}
 	updatedUser, err = cfg.db.UpdateOneUser(r.Context(), updateParams)
+	if isUniqueViolation(err) {
+		writeJSON(w, 409, errorParameters{Body: "Email or handle already taken"})
+		return
+	}
 	if err != nil {
 		writeJSON(w, 500, errorParameters{Body: "DB Update failed!"})
 		return
 	}
+	if passwordChanged {
+		err = cfg.db.RefreshTokensRevokeForUser(r.Context(), token_user)
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "DB Error, could not revoke refresh tokens"})
+			return
+		}
+	}
+	counts, err := cfg.db.UserFollowCounts(r.Context(), updatedUser.ID)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not count followers"})
+		return
+	}
 	
 	w.WriteHeader(200)
-	resp = convertDbUser(updatedUser)
+	resp = convertDbUser(updatedUser, counts)
 
 	dat, err := json.Marshal(resp)
 	if err != nil {
@@ -469,6 +1367,132 @@ func (cfg *apiConfig) handlerUpdateUser(w http.ResponseWriter, r *http.Request)
 	w.Write(dat)
 }
 
+func (cfg *apiConfig) handlerPatchUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	user := userPatchParameters{}
+	err := decoder.Decode(&user)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: "Invalid Body"})
+		return
+	}
+
+	patchParams := database.PatchUserParams{ID: token_user}
+
+	// Email and password are the login credentials, so changing either
+	// has to be confirmed with the password the user has now.
+	if user.Email != nil || user.Password != nil {
+		userDB, err := cfg.db.GetUserByID(r.Context(), token_user)
+		if err != nil {
+			writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+			return
+		}
+		if auth.CheckPasswordHash(userDB.HashedPassword, user.CurrentPassword) != nil {
+			writeJSON(w, 401, errorParameters{Body: "Invalid current password"})
+			return
+		}
+	}
+
+	if user.Email != nil {
+		if *user.Email == "" {
+			writeJSON(w, 400, errorParameters{Body: "email cannot be empty"})
+			return
+		}
+		patchParams.Email = sql.NullString{String: *user.Email, Valid: true}
+	}
+	if user.Password != nil {
+		if *user.Password == "" {
+			writeJSON(w, 400, errorParameters{Body: "password cannot be empty"})
+			return
+		}
+		password, err := auth.HashPassword(*user.Password)
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "PW Hash fail!"})
+			return
+		}
+		patchParams.HashedPassword = sql.NullString{String: password, Valid: true}
+	}
+	if user.Handle != nil {
+		patchParams.Handle, err = parseHandle(*user.Handle)
+		if err != nil {
+			writeJSON(w, 400, errorParameters{Body: err.Error()})
+			return
+		}
+	}
+	profile, err := parseProfile(userCreateParameters{DisplayName: user.DisplayName, Bio: user.Bio, AvatarUrl: user.AvatarUrl})
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: err.Error()})
+		return
+	}
+	patchParams.DisplayName = profile.DisplayName
+	patchParams.Bio = profile.Bio
+	patchParams.AvatarUrl = profile.AvatarUrl
+	if user.IsPrivate != nil {
+		patchParams.IsPrivate = sql.NullBool{Bool: *user.IsPrivate, Valid: true}
+	}
+
+	updatedUser, err := cfg.db.PatchUser(r.Context(), patchParams)
+	if isUniqueViolation(err) {
+		writeJSON(w, 409, errorParameters{Body: "Email or handle already taken"})
+		return
+	}
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Update failed!"})
+		return
+	}
+
+	if patchParams.HashedPassword.Valid {
+		err = cfg.db.RefreshTokensRevokeForUser(r.Context(), token_user)
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "DB Error, could not revoke refresh tokens"})
+			return
+		}
+	}
+	// Going public lets everyone follow, so nobody is left waiting.
+	if user.IsPrivate != nil && !*user.IsPrivate {
+		err = cfg.db.FollowRequestsApproveAll(r.Context(), token_user)
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "DB Error, could not approve follow requests"})
+			return
+		}
+	}
+
+	counts, err := cfg.db.UserFollowCounts(r.Context(), updatedUser.ID)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not count followers"})
+		return
+	}
+	writeJSON(w, 200, convertDbUser(updatedUser, counts))
+}
+
+func (cfg *apiConfig) handlerDeleteMe(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	userDB, err := cfg.db.UserRequestDeletion(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+	err = cfg.db.RefreshTokensRevokeForUser(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not revoke refresh tokens"})
+		return
+	}
+
+	writeJSON(w, 202, deletionParameters{DeleteAfter: userDB.DeletionRequestedAt.Time.Add(cfg.deletion_grace)})
+}
+
 func (cfg *apiConfig) handlerPolkaWebhook(w http.ResponseWriter, r *http.Request) {
 
 	decoder := json.NewDecoder(r.Body)
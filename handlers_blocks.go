@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/google/uuid"
+)
+
+// relatedUser is one entry of the caller's block or mute list.
+type relatedUser struct {
+	UserId  uuid.UUID `json:"user_id"`
+	Created time.Time `json:"created_at"`
+}
+
+// targetUser reads the {userId} path value for block and mute routes,
+// which never make sense against the caller's own account.
+func targetUser(w http.ResponseWriter, r *http.Request, token_user uuid.UUID) (uuid.UUID, bool) {
+	userId := r.PathValue("userId")
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting userId to UUID: %v\nErr: %v", userId, err)})
+		return uuid.Nil, false
+	}
+	if userUUID == token_user {
+		writeJSON(w, 400, errorParameters{Body: "Users cannot block or mute themselves"})
+		return uuid.Nil, false
+	}
+	return userUUID, true
+}
+
+// handlerBlockUser also drops any follows between the two users. Blocks are
+// enforced in both directions by the chirp queries themselves.
+func (cfg *apiConfig) handlerBlockUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	userUUID, ok := targetUser(w, r, token_user)
+	if !ok {
+		return
+	}
+	if _, err := cfg.db.GetUserByID(r.Context(), userUUID); err != nil {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+
+	err := cfg.db.BlockAdd(r.Context(), database.BlockAddParams{BlockerID: token_user, BlockedID: userUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not block user"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+func (cfg *apiConfig) handlerUnblockUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	userUUID, ok := targetUser(w, r, token_user)
+	if !ok {
+		return
+	}
+
+	err := cfg.db.BlockDelete(r.Context(), database.BlockDeleteParams{BlockerID: token_user, BlockedID: userUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not unblock user"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+// handlerMuteUser hides the user's chirps from the caller's reads only; the
+// muted user can still see and interact with the caller as before.
+func (cfg *apiConfig) handlerMuteUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	userUUID, ok := targetUser(w, r, token_user)
+	if !ok {
+		return
+	}
+	if _, err := cfg.db.GetUserByID(r.Context(), userUUID); err != nil {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+
+	err := cfg.db.MuteAdd(r.Context(), database.MuteAddParams{MuterID: token_user, MutedID: userUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not mute user"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+func (cfg *apiConfig) handlerUnmuteUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	userUUID, ok := targetUser(w, r, token_user)
+	if !ok {
+		return
+	}
+
+	err := cfg.db.MuteDelete(r.Context(), database.MuteDeleteParams{MuterID: token_user, MutedID: userUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not unmute user"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+func (cfg *apiConfig) handlerGetBlocks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	blocks, err := cfg.db.BlocksGet(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Blocks: %v", err)})
+		return
+	}
+	users := []relatedUser{}
+	for _, block := range blocks {
+		users = append(users, relatedUser{UserId: block.BlockedID, Created: block.CreatedAt})
+	}
+	writeJSON(w, 200, users)
+}
+
+func (cfg *apiConfig) handlerGetMutes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	mutes, err := cfg.db.MutesGet(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Mutes: %v", err)})
+		return
+	}
+	users := []relatedUser{}
+	for _, mute := range mutes {
+		users = append(users, relatedUser{UserId: mute.MutedID, Created: mute.CreatedAt})
+	}
+	writeJSON(w, 200, users)
+}
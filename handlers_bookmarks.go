@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/AkuPython/Chirpy/internal/pagination"
+	"github.com/google/uuid"
+)
+
+// handlerBookmarkChirp saves a chirp for the caller. Bookmarks are only ever
+// shown to the user who made them; unlike likes they are never counted.
+func (cfg *apiConfig) handlerBookmarkChirp(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+	if _, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: chirpUUID, ViewerID: token_user}); err != nil {
+		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+
+	err = cfg.db.ChirpBookmarkAdd(r.Context(), database.ChirpBookmarkAddParams{ChirpID: chirpUUID, UserID: token_user})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not bookmark chirp"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+func (cfg *apiConfig) handlerUnbookmarkChirp(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+
+	err = cfg.db.ChirpBookmarkDelete(r.Context(), database.ChirpBookmarkDeleteParams{ChirpID: chirpUUID, UserID: token_user})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not remove bookmark"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+// handlerGetBookmarks lists the caller's bookmarks, most recently saved
+// first. The cursor holds the bookmark time rather than the chirp's.
+func (cfg *apiConfig) handlerGetBookmarks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := pagination.ParseQuery(r.URL.Query())
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Invalid pagination: %v", err)})
+		return
+	}
+
+	rows, err := cfg.db.BookmarksGet(r.Context(), database.BookmarksGetParams{
+		UserID:          token_user,
+		HasCursor:       page.HasCursor,
+		CursorCreatedAt: page.Cursor.CreatedAt,
+		CursorID:        page.Cursor.ID,
+		RowLimit:        page.Limit + 1,
+	})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Bookmarks: %v", err)})
+		return
+	}
+
+	resp := chirpPage{}
+	if len(rows) > int(page.Limit) {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		resp.NextCursor = pagination.EncodeCursor(last.BookmarkedAt, last.Chirp.ID)
+	}
+	dbChirps := make([]database.Chirp, 0, len(rows))
+	for _, row := range rows {
+		dbChirps = append(dbChirps, row.Chirp)
+	}
+	resp.Chirps, err = cfg.convertDbChirps(r.Context(), token_user, dbChirps)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Bookmarks: %v", err)})
+		return
+	}
+	writeJSON(w, 200, resp)
+}
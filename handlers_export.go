@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/archive"
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/google/uuid"
+)
+
+const (
+	// Accounts with more chirps than this are exported in the background.
+	exportAsyncThreshold = 1000
+	exportJobTTL         = 24 * time.Hour
+	// Each running export holds a whole archive in memory.
+	exportMaxRunning = 4
+)
+
+var errExportsBusy = errors.New("too many exports running")
+
+type exportJob struct {
+	Id      uuid.UUID `json:"id"`
+	Status  string    `json:"status"`
+	Created time.Time `json:"created_at"`
+	Error   string    `json:"error,omitempty"`
+	userId  uuid.UUID
+	archive []byte
+}
+
+// exportStore keeps background export jobs in memory until they expire.
+type exportStore struct {
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*exportJob
+}
+
+func newExportStore() *exportStore {
+	return &exportStore{jobs: make(map[uuid.UUID]*exportJob)}
+}
+
+// start returns the user's pending or ready export if there is one, and
+// otherwise adds a new job; created tells the caller to run it. Failed jobs
+// are not reused, so asking again retries.
+func (s *exportStore) start(userId uuid.UUID) (job exportJob, created bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	running := 0
+	for _, job := range s.jobs {
+		if job.userId == userId && job.Status != "failed" {
+			return *job, false, nil
+		}
+		if job.Status == "pending" {
+			running++
+		}
+	}
+	if running >= exportMaxRunning {
+		return exportJob{}, false, errExportsBusy
+	}
+	added := &exportJob{Id: uuid.New(), Status: "pending", Created: time.Now().UTC(), userId: userId}
+	s.jobs[added.Id] = added
+	return *added, true, nil
+}
+
+func (s *exportStore) get(id uuid.UUID) (exportJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return exportJob{}, false
+	}
+	return *job, true
+}
+
+func (s *exportStore) finish(id uuid.UUID, dat []byte, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return
+	}
+	if err != nil {
+		job.Status = "failed"
+		job.Error = err.Error()
+		return
+	}
+	job.Status = "ready"
+	job.archive = dat
+}
+
+func (s *exportStore) expire(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, job := range s.jobs {
+		if time.Since(job.Created) > exportJobTTL {
+			delete(s.jobs, id)
+		}
+	}
+	return nil
+}
+
+// sessionExport describes a refresh token without the token itself.
+type sessionExport struct {
+	Created time.Time  `json:"created_at"`
+	Updated time.Time  `json:"updated_at"`
+	Expires time.Time  `json:"expires_at"`
+	Revoked *time.Time `json:"revoked_at"`
+}
+
+func (cfg *apiConfig) writeExport(ctx context.Context, w io.Writer, userDB database.User) error {
+	counts, err := cfg.db.UserFollowCounts(ctx, userDB.ID)
+	if err != nil {
+		return err
+	}
+
+	dbChirps, err := cfg.db.ChirpsByUser(ctx, userDB.ID)
+	if err != nil {
+		return err
+	}
+	chirps := []Chirp{}
+	for _, chirp := range dbChirps {
+		chirps = append(chirps, convertDbChirp(chirp))
+	}
+
+	dbSessions, err := cfg.db.RefreshTokenSessionsForUser(ctx, userDB.ID)
+	if err != nil {
+		return err
+	}
+	sessions := []sessionExport{}
+	for _, session := range dbSessions {
+		export := sessionExport{Created: session.CreatedAt, Updated: session.UpdatedAt, Expires: session.ExpiresAt}
+		if session.RevokedAt.Valid {
+			export.Revoked = &session.RevokedAt.Time
+		}
+		sessions = append(sessions, export)
+	}
+
+	return archive.Write(w, userDB.Email, time.Now().UTC(), []archive.Section{
+		{File: "profile.json", Title: "Profile", Count: 1, Data: convertDbUser(userDB, counts)},
+		{File: "chirps.json", Title: "Chirps", Count: len(chirps), Data: chirps},
+		{File: "sessions.json", Title: "Sessions", Count: len(sessions), Data: sessions},
+	})
+}
+
+func writeZip(w http.ResponseWriter, userId uuid.UUID) {
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="chirpy-%s.zip"`, userId))
+}
+
+func (cfg *apiConfig) handlerExportMe(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	userDB, err := cfg.db.GetUserByID(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+	chirpCount, err := cfg.db.UserChirpCount(r.Context(), database.UserChirpCountParams{
+		UserID:   token_user,
+		ViewerID: token_user,
+	})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not count chirps"})
+		return
+	}
+
+	if chirpCount > exportAsyncThreshold {
+		w.Header().Set("Content-Type", "application/json")
+		job, created, err := cfg.exports.start(token_user)
+		if err != nil {
+			w.Header().Set("Retry-After", "60")
+			writeJSON(w, 503, errorParameters{Body: "Too many exports are running, try again later"})
+			return
+		}
+		if created {
+			go func() {
+				buf := bytes.Buffer{}
+				err := cfg.writeExport(context.Background(), &buf, userDB)
+				if err != nil {
+					log.Printf("Export %v failed: %v", job.Id, err)
+				}
+				cfg.exports.finish(job.Id, buf.Bytes(), err)
+			}()
+		}
+		writeJSON(w, 202, job)
+		return
+	}
+
+	writeZip(w, token_user)
+	w.WriteHeader(200)
+	if err := cfg.writeExport(r.Context(), w, userDB); err != nil {
+		// Headers are gone by now, so a truncated zip is all the client gets.
+		log.Printf("Export for %v failed: %v", token_user, err)
+	}
+}
+
+func (cfg *apiConfig) handlerGetExportJob(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	jobId := r.PathValue("jobId")
+	jobUUID, err := uuid.Parse(jobId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting jobId to UUID: %v\nErr: %v", jobId, err)})
+		return
+	}
+
+	job, ok := cfg.exports.get(jobUUID)
+	if !ok || job.userId != token_user {
+		writeJSON(w, 404, errorParameters{Body: "Could not find export"})
+		return
+	}
+
+	switch job.Status {
+	case "ready":
+		writeZip(w, token_user)
+		w.WriteHeader(200)
+		w.Write(job.archive)
+	case "failed":
+		w.Header().Set("Content-Type", "application/json")
+		writeJSON(w, 500, job)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		writeJSON(w, 202, job)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/google/uuid"
+)
+
+type followRequest struct {
+	UserId  uuid.UUID `json:"user_id"`
+	Handle  string    `json:"handle,omitempty"`
+	Created time.Time `json:"created_at"`
+}
+
+// handlerGetFollowRequests lists the requests waiting on the caller, oldest
+// first.
+func (cfg *apiConfig) handlerGetFollowRequests(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := cfg.db.FollowRequestsGet(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Follow Requests: %v", err)})
+		return
+	}
+	requests := []followRequest{}
+	for _, row := range rows {
+		requests = append(requests, followRequest{UserId: row.RequesterID, Handle: row.Handle.String, Created: row.CreatedAt})
+	}
+	writeJSON(w, 200, requests)
+}
+
+func (cfg *apiConfig) handlerApproveFollowRequest(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	userId := r.PathValue("userId")
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting userId to UUID: %v\nErr: %v", userId, err)})
+		return
+	}
+
+	approved, err := cfg.db.FollowRequestApprove(r.Context(), database.FollowRequestApproveParams{RequesterID: userUUID, TargetID: token_user})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not approve follow request"})
+		return
+	}
+	if approved == 0 {
+		writeJSON(w, 404, errorParameters{Body: "Could not find follow request"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+func (cfg *apiConfig) handlerDenyFollowRequest(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	userId := r.PathValue("userId")
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting userId to UUID: %v\nErr: %v", userId, err)})
+		return
+	}
+
+	denied, err := cfg.db.FollowRequestDelete(r.Context(), database.FollowRequestDeleteParams{RequesterID: userUUID, TargetID: token_user})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not deny follow request"})
+		return
+	}
+	if denied == 0 {
+		writeJSON(w, 404, errorParameters{Body: "Could not find follow request"})
+		return
+	}
+	w.WriteHeader(204)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/AkuPython/Chirpy/internal/pagination"
+	"github.com/google/uuid"
+)
+
+func (cfg *apiConfig) handlerFollowUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	userId := r.PathValue("userId")
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting userId to UUID: %v\nErr: %v", userId, err)})
+		return
+	}
+	if userUUID == token_user {
+		writeJSON(w, 400, errorParameters{Body: "Users cannot follow themselves"})
+		return
+	}
+	followee, err := cfg.db.GetUserByID(r.Context(), userUUID)
+	if err != nil || followee.DeletionRequestedAt.Valid {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+	blocked, err := cfg.db.BlockExists(r.Context(), database.BlockExistsParams{UserA: token_user, UserB: userUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not follow user"})
+		return
+	}
+	if blocked {
+		writeJSON(w, 403, errorParameters{Body: "You cannot follow this user"})
+		return
+	}
+
+	// Private users approve their followers, so ask instead of following.
+	if followee.IsPrivate {
+		err = cfg.db.FollowRequestAdd(r.Context(), database.FollowRequestAddParams{RequesterID: token_user, TargetID: userUUID})
+		if err != nil {
+			writeJSON(w, 500, errorParameters{Body: "DB Error, could not request to follow user"})
+			return
+		}
+		w.WriteHeader(202)
+		return
+	}
+
+	err = cfg.db.FollowAdd(r.Context(), database.FollowAddParams{FollowerID: token_user, FolloweeID: userUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not follow user"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+func (cfg *apiConfig) handlerUnfollowUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	userId := r.PathValue("userId")
+	userUUID, err := uuid.Parse(userId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting userId to UUID: %v\nErr: %v", userId, err)})
+		return
+	}
+
+	err = cfg.db.FollowDelete(r.Context(), database.FollowDeleteParams{FollowerID: token_user, FolloweeID: userUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not unfollow user"})
+		return
+	}
+	// Unfollowing also withdraws a request that is still pending.
+	_, err = cfg.db.FollowRequestDelete(r.Context(), database.FollowRequestDeleteParams{RequesterID: token_user, TargetID: userUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not unfollow user"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+func (cfg *apiConfig) handlerGetTimeline(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := pagination.ParseQuery(r.URL.Query())
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Invalid pagination: %v", err)})
+		return
+	}
+
+	chirps, err := cfg.db.TimelineGet(r.Context(), database.TimelineGetParams{
+		UserID:          token_user,
+		HasCursor:       page.HasCursor,
+		CursorCreatedAt: page.Cursor.CreatedAt,
+		CursorID:        page.Cursor.ID,
+		RowLimit:        page.Limit + 1,
+	})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Timeline: %v", err)})
+		return
+	}
+
+	resp, err := cfg.newChirpPage(r.Context(), token_user, chirps, page.Limit)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Timeline: %v", err)})
+		return
+	}
+	writeJSON(w, 200, resp)
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/archive"
+	"github.com/AkuPython/Chirpy/internal/database"
+)
+
+const (
+	importMaxBytes   = 10 << 20
+	importMaxRecords = 10000
+	// A zip may not inflate past this, however small the upload was.
+	importMaxUnzippedBytes = 50 << 20
+	// Only the first few problems are listed; the counts cover the rest.
+	importMaxProblems = 100
+)
+
+type importProblem struct {
+	Record int    `json:"record"`
+	Result string `json:"result"`
+	Reason string `json:"reason"`
+}
+
+type importReport struct {
+	Imported int             `json:"imported"`
+	Skipped  int             `json:"skipped"`
+	Rejected int             `json:"rejected"`
+	Problems []importProblem `json:"problems"`
+}
+
+func (rep *importReport) add(record int, result, reason string) {
+	if result == "skipped" {
+		rep.Skipped++
+	} else {
+		rep.Rejected++
+	}
+	if len(rep.Problems) < importMaxProblems {
+		rep.Problems = append(rep.Problems, importProblem{Record: record, Result: result, Reason: reason})
+	}
+}
+
+// handlerImportMe loads chirps from a JSON Lines file or a zip archive, such
+// as one produced by the export endpoint, of which only chirps.json is read.
+// Timestamps are kept, the bodies go through the same cleaning as new chirps
+// and everything lands in a single transaction, so a failed import leaves
+// nothing behind.
+func (cfg *apiConfig) handlerImportMe(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	dat, err := io.ReadAll(http.MaxBytesReader(w, r.Body, importMaxBytes))
+	if err != nil {
+		writeJSON(w, 413, errorParameters{Body: fmt.Sprintf("Import must be under %d bytes", importMaxBytes)})
+		return
+	}
+	records, err := archive.ReadRecords(dat, importMaxUnzippedBytes)
+	if errors.Is(err, archive.ErrTooLarge) {
+		writeJSON(w, 413, errorParameters{Body: fmt.Sprintf("Import must be under %d bytes once unzipped", importMaxUnzippedBytes)})
+		return
+	}
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Could not read import: %v", err)})
+		return
+	}
+	if len(records) > importMaxRecords {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Import is limited to %d chirps", importMaxRecords)})
+		return
+	}
+
+	limit, err := cfg.chirpLimit(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+
+	tx, err := cfg.conn.BeginTx(r.Context(), nil)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not start import"})
+		return
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	report := importReport{Problems: []importProblem{}}
+	now := time.Now().UTC()
+	for _, record := range records {
+		if errors.Is(record.Err, archive.ErrLineTooLong) {
+			report.add(record.Index, "rejected", "Line is too long")
+			continue
+		}
+		if record.Err != nil {
+			report.add(record.Index, "rejected", "Malformed JSON")
+			continue
+		}
+		if strings.TrimSpace(record.Body) == "" {
+			report.add(record.Index, "skipped", "Empty body")
+			continue
+		}
+		created, err := time.Parse(time.RFC3339Nano, record.CreatedAt)
+		if err != nil {
+			report.add(record.Index, "rejected", "created_at must be an RFC 3339 timestamp")
+			continue
+		}
+		created = created.UTC()
+		if created.After(now) {
+			report.add(record.Index, "rejected", "created_at is in the future")
+			continue
+		}
+		body, rules, err := cfg.cleanChirpBody(record.Body, limit)
+		if err != nil {
+			report.add(record.Index, "rejected", err.Error())
+			continue
+		}
+
+		chirp, err := qtx.ChirpImport(r.Context(), database.ChirpImportParams{
+			CreatedAt: created,
+			Body:      body,
+			UserID:    token_user,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			report.add(record.Index, "skipped", "Chirp already exists")
+			continue
+		}
+		if err != nil {
+			log.Printf("Import for %v failed: %v", token_user, err)
+			writeJSON(w, 500, errorParameters{Body: "Import failed, nothing was imported"})
+			return
+		}
+		if err := saveChirpEntities(r.Context(), qtx, chirp, rules, false, false); err != nil {
+			log.Printf("Import for %v failed: %v", token_user, err)
+			writeJSON(w, 500, errorParameters{Body: "Import failed, nothing was imported"})
+			return
+		}
+		report.Imported++
+	}
+
+	if err := tx.Commit(); err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Import failed, nothing was imported"})
+		return
+	}
+	writeJSON(w, 200, report)
+}
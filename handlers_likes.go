@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/google/uuid"
+)
+
+func (cfg *apiConfig) handlerLikeChirp(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+	if _, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: chirpUUID, ViewerID: token_user}); err != nil {
+		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+
+	err = cfg.db.ChirpLikeAdd(r.Context(), database.ChirpLikeAddParams{ChirpID: chirpUUID, UserID: token_user})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not like chirp"})
+		return
+	}
+	w.WriteHeader(204)
+}
+
+func (cfg *apiConfig) handlerUnlikeChirp(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+
+	err = cfg.db.ChirpLikeDelete(r.Context(), database.ChirpLikeDeleteParams{ChirpID: chirpUUID, UserID: token_user})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not unlike chirp"})
+		return
+	}
+	w.WriteHeader(204)
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/AkuPython/Chirpy/internal/media"
+	"github.com/google/uuid"
+)
+
+const (
+	maxChirpMedia = 4
+	// Uploads that never make it onto a chirp are removed after this long.
+	unattachedMediaTTL = 24 * time.Hour
+)
+
+type chirpMedia struct {
+	Id          uuid.UUID `json:"id"`
+	ContentType string    `json:"content_type"`
+	Url         string    `json:"url"`
+	Width       int32     `json:"width"`
+	Height      int32     `json:"height"`
+	ThumbUrl    string    `json:"thumbnail_url"`
+	ThumbWidth  int32     `json:"thumbnail_width"`
+	ThumbHeight int32     `json:"thumbnail_height"`
+}
+
+func (cfg *apiConfig) newChirpMedia(m database.MediaFile) chirpMedia {
+	return chirpMedia{
+		Id:          m.ID,
+		ContentType: m.ContentType,
+		Url:         cfg.media.URL(m.StorageKey),
+		Width:       m.Width,
+		Height:      m.Height,
+		ThumbUrl:    cfg.media.URL(m.ThumbKey),
+		ThumbWidth:  m.ThumbWidth,
+		ThumbHeight: m.ThumbHeight,
+	}
+}
+
+// checkMediaIds validates the media_ids of a new chirp. Ownership is
+// checked when they are attached.
+func checkMediaIds(ids []uuid.UUID) error {
+	if len(ids) > maxChirpMedia {
+		return fmt.Errorf("A chirp can have at most %d attachments", maxChirpMedia)
+	}
+	seen := make(map[uuid.UUID]bool)
+	for _, id := range ids {
+		if seen[id] {
+			return fmt.Errorf("Attachment %v is listed twice", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// handlerUploadMedia takes a multipart form with the image in the "file"
+// field and returns the media object to reference from a chirp.
+func (cfg *apiConfig) handlerUploadMedia(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	// Leave some room for the multipart framing around the file.
+	r.Body = http.MaxBytesReader(w, r.Body, media.MaxBytes+1<<20)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var tooBig *http.MaxBytesError
+		if errors.As(err, &tooBig) {
+			writeJSON(w, 413, errorParameters{Body: fmt.Sprintf("Uploads must be under %d bytes", media.MaxBytes)})
+			return
+		}
+		writeJSON(w, 400, errorParameters{Body: "Expected an image in the file field"})
+		return
+	}
+	defer file.Close()
+
+	dat, err := io.ReadAll(io.LimitReader(file, media.MaxBytes+1))
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: "Could not read upload"})
+		return
+	}
+	if len(dat) > media.MaxBytes {
+		writeJSON(w, 413, errorParameters{Body: fmt.Sprintf("Uploads must be under %d bytes", media.MaxBytes)})
+		return
+	}
+
+	img, err := media.Process(dat)
+	if errors.Is(err, media.ErrUnsupported) {
+		writeJSON(w, 415, errorParameters{Body: "Only JPEG, PNG and GIF images are supported"})
+		return
+	}
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: err.Error()})
+		return
+	}
+
+	id := uuid.New()
+	key := id.String() + img.Ext
+	thumbKey := id.String() + "_thumb" + img.ThumbExt
+	if err := cfg.media.Put(r.Context(), key, img.Data, img.ContentType); err != nil {
+		log.Printf("Storing media %v failed: %v", id, err)
+		writeJSON(w, 500, errorParameters{Body: "Could not store upload"})
+		return
+	}
+	if err := cfg.media.Put(r.Context(), thumbKey, img.Thumb, img.ThumbContentType); err != nil {
+		log.Printf("Storing media %v failed: %v", id, err)
+		cfg.media.Delete(r.Context(), key)
+		writeJSON(w, 500, errorParameters{Body: "Could not store upload"})
+		return
+	}
+
+	dbMedia, err := cfg.db.MediaAdd(r.Context(), database.MediaAddParams{
+		ID:          id,
+		UserID:      uuid.NullUUID{UUID: token_user, Valid: true},
+		ContentType: img.ContentType,
+		Width:       int32(img.Width),
+		Height:      int32(img.Height),
+		SizeBytes:   int64(len(img.Data)),
+		StorageKey:  key,
+		ThumbKey:    thumbKey,
+		ThumbWidth:  int32(img.ThumbWidth),
+		ThumbHeight: int32(img.ThumbHeight),
+	})
+	if err != nil {
+		cfg.media.Delete(r.Context(), key)
+		cfg.media.Delete(r.Context(), thumbKey)
+		writeJSON(w, 500, errorParameters{Body: "Could not save upload"})
+		return
+	}
+	writeJSON(w, 201, cfg.newChirpMedia(dbMedia))
+}
+
+// purgeUnattachedMedia removes uploads that were never attached to a chirp,
+// or whose chirp has since been deleted, together with their files.
+func (cfg *apiConfig) purgeUnattachedMedia(ctx context.Context) error {
+	purged, err := cfg.db.MediaPurgeUnattached(ctx, time.Now().UTC().Add(-unattachedMediaTTL))
+	if err != nil {
+		return err
+	}
+	for _, m := range purged {
+		if err := cfg.media.Delete(ctx, m.StorageKey); err != nil {
+			log.Printf("Deleting %s failed: %v", m.StorageKey, err)
+		}
+		if err := cfg.media.Delete(ctx, m.ThumbKey); err != nil {
+			log.Printf("Deleting %s failed: %v", m.ThumbKey, err)
+		}
+	}
+	if len(purged) > 0 {
+		log.Printf("Purged %d unattached media files", len(purged))
+	}
+	return nil
+}
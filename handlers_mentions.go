@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/AkuPython/Chirpy/internal/pagination"
+)
+
+func (cfg *apiConfig) handlerGetMentions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := pagination.ParseQuery(r.URL.Query())
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Invalid pagination: %v", err)})
+		return
+	}
+
+	chirps, err := cfg.db.MentionsGet(r.Context(), database.MentionsGetParams{
+		UserID:          token_user,
+		HasCursor:       page.HasCursor,
+		CursorCreatedAt: page.Cursor.CreatedAt,
+		CursorID:        page.Cursor.ID,
+		RowLimit:        page.Limit + 1,
+	})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Mentions: %v", err)})
+		return
+	}
+
+	resp, err := cfg.newChirpPage(r.Context(), token_user, chirps, page.Limit)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Mentions: %v", err)})
+		return
+	}
+	writeJSON(w, 200, resp)
+}
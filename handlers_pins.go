@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/google/uuid"
+)
+
+type pinParameters struct {
+	ChirpId uuid.UUID `json:"chirp_id"`
+}
+
+// pinnedChirp returns the author's pinned chirp as viewer sees it, or nil
+// when nothing visible is pinned. ChirpsGet leaves it out of the listing,
+// so profiles show it once, ahead of everything else. It is part of the
+// listing, so an unlisted pin or a muted author shows nothing.
+func (cfg *apiConfig) pinnedChirp(ctx context.Context, viewer uuid.UUID, authorId uuid.UUID) (*Chirp, error) {
+	author, err := cfg.db.GetUserByID(ctx, authorId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !author.PinnedChirpID.Valid {
+		return nil, nil
+	}
+	dbChirp, err := cfg.db.ChirpGetListed(ctx, database.ChirpGetListedParams{ID: author.PinnedChirpID.UUID, ViewerID: viewer})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	chirp, err := cfg.convertOneDbChirp(ctx, viewer, dbChirp)
+	if err != nil {
+		return nil, err
+	}
+	chirp.Pinned = true
+	return &chirp, nil
+}
+
+// prependPinned puts the author's pinned chirp at the top of the first page
+// of a profile listing.
+func (cfg *apiConfig) prependPinned(ctx context.Context, viewer uuid.UUID, authorId uuid.UUID, hasCursor bool, page *chirpPage) error {
+	if authorId == uuid.Nil || hasCursor {
+		return nil
+	}
+	pinned, err := cfg.pinnedChirp(ctx, viewer, authorId)
+	if err != nil || pinned == nil {
+		return err
+	}
+	page.Chirps = append([]Chirp{*pinned}, page.Chirps...)
+	return nil
+}
+
+func (cfg *apiConfig) handlerPinChirp(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	params := pinParameters{}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeJSON(w, 400, errorParameters{Body: "Something went wrong"})
+		return
+	}
+	chirp, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: params.ChirpId, ViewerID: token_user})
+	if err != nil {
+		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v\nErr: %v", params.ChirpId, err)})
+		return
+	}
+	if chirp.UserID != token_user {
+		writeJSON(w, 403, errorParameters{Body: "Wrong user for pin!"})
+		return
+	}
+
+	err = cfg.db.UserSetPinnedChirp(r.Context(), database.UserSetPinnedChirpParams{
+		ID:            token_user,
+		PinnedChirpID: uuid.NullUUID{UUID: chirp.ID, Valid: true},
+	})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not pin chirp"})
+		return
+	}
+	jsonChirp, err := cfg.convertOneDbChirp(r.Context(), token_user, chirp)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirp: %v", err)})
+		return
+	}
+	jsonChirp.Pinned = true
+	writeJSON(w, 200, jsonChirp)
+}
+
+func (cfg *apiConfig) handlerUnpinChirp(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	err := cfg.db.UserSetPinnedChirp(r.Context(), database.UserSetPinnedChirpParams{ID: token_user})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "DB Error, could not unpin chirp"})
+		return
+	}
+	w.WriteHeader(204)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/chirptext"
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/google/uuid"
+)
+
+const (
+	minPollOptions   = 2
+	maxPollOptions   = 4
+	pollOptionLength = 25
+	minPollDuration  = 5 * time.Minute
+	maxPollDuration  = 7 * 24 * time.Hour
+)
+
+type pollParameters struct {
+	Options  []string  `json:"options"`
+	ClosesAt time.Time `json:"closes_at"`
+}
+
+type voteParameters struct {
+	OptionId uuid.UUID `json:"option_id"`
+}
+
+// pollOption carries a nil Votes while the tally is hidden from the viewer.
+type pollOption struct {
+	Id    uuid.UUID `json:"id"`
+	Label string    `json:"label"`
+	Votes *int64    `json:"votes"`
+}
+
+type chirpPoll struct {
+	ClosesAt      time.Time    `json:"closes_at"`
+	Closed        bool         `json:"closed"`
+	Options       []pollOption `json:"options"`
+	TotalVotes    *int64       `json:"total_votes"`
+	VotedOptionId *uuid.UUID   `json:"voted_option_id"`
+}
+
+// checkPoll validates the poll on a new chirp and returns its cleaned option
+// labels. A scheduled chirp's poll has to stay open past its publish time.
+func (cfg *apiConfig) checkPoll(poll pollParameters, publishAt sql.NullTime) ([]string, error) {
+	if len(poll.Options) < minPollOptions || len(poll.Options) > maxPollOptions {
+		return nil, fmt.Errorf("Polls need %d to %d options", minPollOptions, maxPollOptions)
+	}
+	labels := []string{}
+	seen := make(map[string]bool)
+	for _, option := range poll.Options {
+		label := strings.TrimSpace(option)
+		if label == "" {
+			return nil, fmt.Errorf("Poll options cannot be empty")
+		}
+		if length := chirptext.Length(label); length > pollOptionLength {
+			return nil, fmt.Errorf("Poll option is too long: %d characters, the limit is %d", length, pollOptionLength)
+		}
+		res := cfg.profanity.Check(label)
+		if res.Rejected {
+			return nil, fmt.Errorf("Poll option contains blocked language")
+		}
+		key := strings.ToLower(res.Text)
+		if seen[key] {
+			return nil, fmt.Errorf("Poll options must be different")
+		}
+		seen[key] = true
+		labels = append(labels, res.Text)
+	}
+
+	opens := time.Now().UTC()
+	if publishAt.Valid {
+		opens = publishAt.Time
+	}
+	if err := checkPollDuration(opens, poll.ClosesAt); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// checkPollDuration checks a poll that opens when its chirp is published.
+// Rescheduling a chirp runs it again, since the poll's closes_at stays put.
+func checkPollDuration(opens, closesAt time.Time) error {
+	duration := closesAt.Sub(opens)
+	if duration < minPollDuration || duration > maxPollDuration {
+		return fmt.Errorf("Polls must run between %v and %d days", minPollDuration, int(maxPollDuration.Hours()/24))
+	}
+	return nil
+}
+
+// loadPolls builds the polls for a batch of chirps as viewer sees them.
+func (cfg *apiConfig) loadPolls(ctx context.Context, viewer uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]*chirpPoll, error) {
+	polls := make(map[uuid.UUID]*chirpPoll)
+	rows, err := cfg.db.PollsForChirps(ctx, ids)
+	if err != nil || len(rows) == 0 {
+		return polls, err
+	}
+
+	voted := make(map[uuid.UUID]uuid.UUID)
+	if viewer != uuid.Nil {
+		votes, err := cfg.db.PollVotesBy(ctx, database.PollVotesByParams{UserID: viewer, ChirpIds: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, vote := range votes {
+			voted[vote.ChirpID] = vote.OptionID
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, row := range rows {
+		poll, ok := polls[row.ChirpID]
+		if !ok {
+			poll = &chirpPoll{ClosesAt: row.ClosesAt, Closed: !row.ClosesAt.After(now), Options: []pollOption{}}
+			if optionId, ok := voted[row.ChirpID]; ok {
+				poll.VotedOptionId = &optionId
+			}
+			if poll.Closed || poll.VotedOptionId != nil {
+				poll.TotalVotes = new(int64)
+			}
+			polls[row.ChirpID] = poll
+		}
+		option := pollOption{Id: row.OptionID, Label: row.Label}
+		if poll.TotalVotes != nil {
+			votes := row.VoteCount
+			option.Votes = &votes
+			*poll.TotalVotes += votes
+		}
+		poll.Options = append(poll.Options, option)
+	}
+	return polls, nil
+}
+
+func (cfg *apiConfig) handlerVotePoll(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+	params := voteParameters{}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeJSON(w, 400, errorParameters{Body: "Something went wrong"})
+		return
+	}
+
+	if _, err := cfg.db.ChirpGet(r.Context(), database.ChirpGetParams{ID: chirpUUID, ViewerID: token_user}); err != nil {
+		writeJSON(w, 404, errorParameters{Body: fmt.Sprintf("Error Getting ChirpID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+	polls, err := cfg.loadPolls(r.Context(), token_user, []uuid.UUID{chirpUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not get poll"})
+		return
+	}
+	poll, ok := polls[chirpUUID]
+	if !ok {
+		writeJSON(w, 404, errorParameters{Body: "Chirp has no poll"})
+		return
+	}
+	if poll.Closed {
+		writeJSON(w, 409, errorParameters{Body: "Poll is closed"})
+		return
+	}
+	if poll.VotedOptionId != nil {
+		writeJSON(w, 409, errorParameters{Body: "Already voted"})
+		return
+	}
+
+	added, err := cfg.db.PollVoteAdd(r.Context(), database.PollVoteAddParams{
+		UserID:   token_user,
+		OptionID: params.OptionId,
+		ChirpID:  chirpUUID,
+	})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Voting Failed!"})
+		return
+	}
+	if added == 0 {
+		// Either the option is not part of this poll, or the poll closed or
+		// the user voted since it was loaded.
+		writeJSON(w, 400, errorParameters{Body: "Vote was not counted; check option_id"})
+		return
+	}
+
+	polls, err = cfg.loadPolls(r.Context(), token_user, []uuid.UUID{chirpUUID})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not get poll"})
+		return
+	}
+	writeJSON(w, 201, polls[chirpUUID])
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/auth"
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/AkuPython/Chirpy/internal/profanity"
+	"github.com/google/uuid"
+)
+
+// defaultProfanityWords is used when no PROFANITY_WORDS_FILE is configured.
+var defaultProfanityWords = []string{"kerfuffle", "sharbert", "fornax"}
+
+// loadProfanityFilter builds the filter from the word list file, falling
+// back to defaultProfanityWords, plus the words admins added at runtime.
+func loadProfanityFilter(ctx context.Context, db *database.Queries, path string, mode profanity.Mode) (*profanity.Filter, error) {
+	words := defaultProfanityWords
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		words, err = profanity.ReadWords(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+	}
+	filter := profanity.New(mode, words...)
+
+	added, err := db.ProfanityWordsGet(ctx)
+	if err != nil {
+		return filter, fmt.Errorf("loading admin words: %w", err)
+	}
+	filter.Add(added...)
+	return filter, nil
+}
+
+// isAdmin checks the ApiKey in the Authorization header against ADMIN_KEY.
+// Admin endpoints are disabled when no key is configured.
+func (cfg *apiConfig) isAdmin(r *http.Request) bool {
+	key, err := auth.GetAPIKey(r.Header)
+	return err == nil && cfg.admin_key != "" && key == cfg.admin_key
+}
+
+type profanitySettings struct {
+	Mode  profanity.Mode `json:"mode"`
+	Words []string       `json:"words"`
+}
+
+type profanityWordParameters struct {
+	Word string `json:"word"`
+}
+
+type filterHit struct {
+	Rule    string    `json:"rule"`
+	Created time.Time `json:"created_at"`
+}
+
+func (cfg *apiConfig) handlerGetProfanity(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if !cfg.isAdmin(r) {
+		writeJSON(w, 401, errorParameters{Body: "Admin key required"})
+		return
+	}
+	writeJSON(w, 200, profanitySettings{Mode: cfg.profanity.Mode(), Words: cfg.profanity.Words()})
+}
+
+func (cfg *apiConfig) handlerAddProfanityWord(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if !cfg.isAdmin(r) {
+		writeJSON(w, 401, errorParameters{Body: "Admin key required"})
+		return
+	}
+
+	params := profanityWordParameters{}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeJSON(w, 400, errorParameters{Body: "Something went wrong"})
+		return
+	}
+	word := profanity.Normalize(params.Word)
+	if word == "" {
+		writeJSON(w, 400, errorParameters{Body: "Word must contain letters or digits"})
+		return
+	}
+	if err := cfg.db.ProfanityWordAdd(r.Context(), word); err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not save word"})
+		return
+	}
+	cfg.profanity.Add(word)
+	writeJSON(w, 201, profanityWordParameters{Word: word})
+}
+
+// handlerDeleteProfanityWord removes a word from the running filter. Words
+// that came from the word list file return on the next restart.
+func (cfg *apiConfig) handlerDeleteProfanityWord(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if !cfg.isAdmin(r) {
+		writeJSON(w, 401, errorParameters{Body: "Admin key required"})
+		return
+	}
+
+	word := profanity.Normalize(r.PathValue("word"))
+	if _, err := cfg.db.ProfanityWordDelete(r.Context(), word); err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not delete word"})
+		return
+	}
+	cfg.profanity.Remove(word)
+	w.WriteHeader(204)
+}
+
+func (cfg *apiConfig) handlerGetChirpFilterHits(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if !cfg.isAdmin(r) {
+		writeJSON(w, 401, errorParameters{Body: "Admin key required"})
+		return
+	}
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+	dbHits, err := cfg.db.ChirpFilterHitsGet(r.Context(), chirpUUID)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not get filter hits"})
+		return
+	}
+	hits := []filterHit{}
+	for _, hit := range dbHits {
+		hits = append(hits, filterHit{Rule: hit.Rule, Created: hit.CreatedAt})
+	}
+	writeJSON(w, 200, hits)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AkuPython/Chirpy/internal/chirptext"
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/google/uuid"
+)
+
+const (
+	maxDisplayNameLength = 50
+	maxBioLength         = 160
+)
+
+// userProfile is the public view of a user, so it never carries the email.
+type userProfile struct {
+	Id             uuid.UUID  `json:"id"`
+	Created        time.Time  `json:"created_at"`
+	Handle         string     `json:"handle"`
+	DisplayName    string     `json:"display_name"`
+	Bio            string     `json:"bio"`
+	AvatarUrl      string     `json:"avatar_url"`
+	IsRed          bool       `json:"is_chirpy_red"`
+	IsPrivate      bool       `json:"is_private"`
+	FollowerCount  int64      `json:"follower_count"`
+	FollowingCount int64      `json:"following_count"`
+	ChirpCount     int64      `json:"chirp_count"`
+	PinnedChirpId  *uuid.UUID `json:"pinned_chirp_id"`
+}
+
+// profileFields holds the optional profile columns of an update. A NULL
+// field leaves the column as it is; an empty string clears it.
+type profileFields struct {
+	DisplayName sql.NullString
+	Bio         sql.NullString
+	AvatarUrl   sql.NullString
+}
+
+func parseProfile(params userCreateParameters) (profileFields, error) {
+	profile := profileFields{}
+
+	if params.DisplayName != nil {
+		name := strings.TrimSpace(*params.DisplayName)
+		if utf8.RuneCountInString(name) > maxDisplayNameLength {
+			return profileFields{}, fmt.Errorf("display_name is longer than %d characters", maxDisplayNameLength)
+		}
+		profile.DisplayName = sql.NullString{String: name, Valid: true}
+	}
+
+	if params.Bio != nil {
+		bio := strings.TrimSpace(*params.Bio)
+		if utf8.RuneCountInString(bio) > maxBioLength {
+			return profileFields{}, fmt.Errorf("bio is longer than %d characters", maxBioLength)
+		}
+		profile.Bio = sql.NullString{String: bio, Valid: true}
+	}
+
+	if params.AvatarUrl != nil {
+		avatar := strings.TrimSpace(*params.AvatarUrl)
+		if avatar != "" {
+			u, err := url.Parse(avatar)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return profileFields{}, fmt.Errorf("avatar_url must be an http or https URL")
+			}
+		}
+		profile.AvatarUrl = sql.NullString{String: avatar, Valid: true}
+	}
+
+	return profile, nil
+}
+
+func (cfg *apiConfig) handlerGetUserProfile(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	handle, err := chirptext.NormalizeHandle(r.PathValue("handle"))
+	if err != nil {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+
+	userDB, err := cfg.db.GetUserByHandle(r.Context(), handle)
+	if err != nil {
+		writeJSON(w, 404, errorParameters{Body: "Could not find user"})
+		return
+	}
+
+	counts, err := cfg.db.UserFollowCounts(r.Context(), userDB.ID)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not count followers"})
+		return
+	}
+	// Only count the chirps this viewer could actually read, so the number
+	// does not leak followers-only or direct chirps.
+	chirpCount, err := cfg.db.UserChirpCount(r.Context(), database.UserChirpCountParams{
+		UserID:   userDB.ID,
+		ViewerID: cfg.getViewer(r),
+	})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not count chirps"})
+		return
+	}
+
+	var pinnedId *uuid.UUID
+	if userDB.PinnedChirpID.Valid {
+		pinnedId = &userDB.PinnedChirpID.UUID
+	}
+	writeJSON(w, 200, userProfile{
+		Id:             userDB.ID,
+		Created:        userDB.CreatedAt,
+		Handle:         userDB.Handle.String,
+		DisplayName:    userDB.DisplayName.String,
+		Bio:            userDB.Bio.String,
+		AvatarUrl:      userDB.AvatarUrl.String,
+		IsRed:          userDB.IsChirpyRed,
+		IsPrivate:      userDB.IsPrivate,
+		FollowerCount:  counts.FollowerCount,
+		FollowingCount: counts.FollowingCount,
+		ChirpCount:     chirpCount,
+		PinnedChirpId:  pinnedId,
+	})
+}
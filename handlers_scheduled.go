@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/google/uuid"
+)
+
+const (
+	maxScheduleAhead = 365 * 24 * time.Hour
+	publishInterval  = 30 * time.Second
+)
+
+type scheduleParameters struct {
+	PublishAt time.Time `json:"publish_at"`
+}
+
+// checkPublishAt turns a requested publish time into the publish_at column.
+// Times that have already passed mean "publish now", which is a NULL.
+func checkPublishAt(publishAt time.Time) (sql.NullTime, error) {
+	now := time.Now().UTC()
+	publishAt = publishAt.UTC()
+	if !publishAt.After(now) {
+		return sql.NullTime{}, nil
+	}
+	if publishAt.Sub(now) > maxScheduleAhead {
+		return sql.NullTime{}, fmt.Errorf("Chirps can be scheduled at most %d days ahead", int(maxScheduleAhead.Hours()/24))
+	}
+	return sql.NullTime{Time: publishAt, Valid: true}, nil
+}
+
+// publishDueChirps makes scheduled chirps visible once their time has come.
+// Their created_at, and that of their tags, moves to the moment they are
+// published, so they land after any cursor a reader already holds and count
+// towards trending from then on.
+func (cfg *apiConfig) publishDueChirps(ctx context.Context) error {
+	published, err := cfg.db.ChirpsPublishDue(ctx, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if published > 0 {
+		log.Printf("Published %d scheduled chirps", published)
+	}
+	return nil
+}
+
+func (cfg *apiConfig) handlerGetScheduled(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	dbChirps, err := cfg.db.ChirpsScheduledGet(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not get scheduled chirps"})
+		return
+	}
+	chirps, err := cfg.convertDbChirps(r.Context(), token_user, dbChirps)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
+		return
+	}
+	writeJSON(w, 200, chirps)
+}
+
+func (cfg *apiConfig) handlerRescheduleChirp(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+	params := scheduleParameters{}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeJSON(w, 400, errorParameters{Body: "Something went wrong"})
+		return
+	}
+	publishAt, err := checkPublishAt(params.PublishAt)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: err.Error()})
+		return
+	}
+	if !publishAt.Valid {
+		// Rescheduling into the past publishes on the next tick.
+		publishAt.Time = time.Now().UTC()
+	}
+	closesAt, err := cfg.db.PollClosesAt(r.Context(), database.PollClosesAtParams{ChirpID: chirpUUID, UserID: token_user})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		writeJSON(w, 500, errorParameters{Body: "Rescheduling Chirp Failed!"})
+		return
+	}
+	if err == nil {
+		if err := checkPollDuration(publishAt.Time, closesAt); err != nil {
+			writeJSON(w, 400, errorParameters{Body: err.Error()})
+			return
+		}
+	}
+
+	chirp, err := cfg.db.ChirpReschedule(r.Context(), database.ChirpRescheduleParams{
+		PublishAt: publishAt.Time,
+		ID:        chirpUUID,
+		UserID:    token_user,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		writeJSON(w, 404, errorParameters{Body: "Could not find scheduled chirp"})
+		return
+	}
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Rescheduling Chirp Failed!"})
+		return
+	}
+	jsonChirp, err := cfg.convertOneDbChirp(r.Context(), token_user, chirp)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirp: %v", err)})
+		return
+	}
+	writeJSON(w, 200, jsonChirp)
+}
+
+func (cfg *apiConfig) handlerCancelScheduled(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+	cancelled, err := cfg.db.ChirpCancelScheduled(r.Context(), database.ChirpCancelScheduledParams{ID: chirpUUID, UserID: token_user})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Cancelling Chirp Failed!"})
+		return
+	}
+	if cancelled == 0 {
+		writeJSON(w, 404, errorParameters{Body: "Could not find scheduled chirp"})
+		return
+	}
+	w.WriteHeader(204)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/AkuPython/Chirpy/internal/pagination"
+	"github.com/google/uuid"
+)
+
+// searchHit.Snippet is HTML: the body is escaped before Postgres wraps
+// the matches in <mark>, so only those tags are ever live.
+type searchHit struct {
+	Chirp
+	Rank    float32 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+type searchPage struct {
+	Chirps     []searchHit `json:"chirps"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// handlerSearchChirps matches `q` with websearch syntax, so quoted phrases,
+// OR and -term all work. Relevance order pages by offset, which the cursor
+// carries in its score; recency order pages by created_at like the list.
+func (cfg *apiConfig) handlerSearchChirps(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeJSON(w, 400, errorParameters{Body: "Missing search query"})
+		return
+	}
+	userUUID, _ := uuid.Parse(r.URL.Query().Get("author_id"))
+
+	order := r.URL.Query().Get("order")
+	if order == "" {
+		order = "relevance"
+	}
+	if order != "relevance" && order != "recent" {
+		writeJSON(w, 400, errorParameters{Body: "order must be relevance or recent"})
+		return
+	}
+	byRank := order == "relevance"
+
+	page, err := pagination.ParseQuery(r.URL.Query())
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Invalid pagination: %v", err)})
+		return
+	}
+	var offset int32
+	if byRank && page.HasCursor {
+		// The cursor comes from the client, so its offset is checked
+		// before it reaches the query.
+		if page.Cursor.Score < 0 || page.Cursor.Score > math.MaxInt32 {
+			writeJSON(w, 400, errorParameters{Body: "Invalid pagination: cursor is out of range"})
+			return
+		}
+		offset = int32(page.Cursor.Score)
+	}
+
+	viewer := cfg.getViewer(r)
+	rows, err := cfg.db.ChirpsSearch(r.Context(), database.ChirpsSearchParams{
+		Query:           query,
+		ViewerID:        viewer,
+		AuthorID:        userUUID,
+		ByRank:          byRank,
+		HasCursor:       page.HasCursor,
+		CursorCreatedAt: page.Cursor.CreatedAt,
+		CursorID:        page.Cursor.ID,
+		RowLimit:        page.Limit + 1,
+		RowOffset:       offset,
+	})
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Searching Chirps: %v", err)})
+		return
+	}
+
+	resp := searchPage{Chirps: []searchHit{}}
+	if len(rows) > int(page.Limit) {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		if byRank {
+			resp.NextCursor = pagination.EncodeScoreCursor(int64(offset+page.Limit), last.Chirp.CreatedAt, last.Chirp.ID)
+		} else {
+			resp.NextCursor = pagination.EncodeCursor(last.Chirp.CreatedAt, last.Chirp.ID)
+		}
+	}
+
+	dbChirps := make([]database.Chirp, 0, len(rows))
+	for _, row := range rows {
+		dbChirps = append(dbChirps, row.Chirp)
+	}
+	jsonChirps, err := cfg.convertDbChirps(r.Context(), viewer, dbChirps)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Searching Chirps: %v", err)})
+		return
+	}
+	for i, row := range rows {
+		resp.Chirps = append(resp.Chirps, searchHit{Chirp: jsonChirps[i], Rank: row.Rank, Snippet: row.Snippet})
+	}
+	writeJSON(w, 200, resp)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/AkuPython/Chirpy/internal/pagination"
+)
+
+const maxTrendingWindow = 7 * 24 * time.Hour
+
+type trendingTag struct {
+	Name     string `json:"name"`
+	UseCount int64  `json:"use_count"`
+}
+
+func (cfg *apiConfig) handlerGetTagChirps(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	tag := strings.ToLower(strings.TrimPrefix(r.PathValue("tag"), "#"))
+
+	page, err := pagination.ParseQuery(r.URL.Query())
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Invalid pagination: %v", err)})
+		return
+	}
+
+	viewer := cfg.getViewer(r)
+	chirps, err := cfg.db.TagChirpsGet(r.Context(), database.TagChirpsGetParams{
+		Name:            tag,
+		ViewerID:        viewer,
+		HasCursor:       page.HasCursor,
+		CursorCreatedAt: page.Cursor.CreatedAt,
+		CursorID:        page.Cursor.ID,
+		RowLimit:        page.Limit + 1,
+	})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
+		return
+	}
+
+	resp, err := cfg.newChirpPage(r.Context(), viewer, chirps, page.Limit)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
+		return
+	}
+	writeJSON(w, 200, resp)
+}
+
+// handlerGetTrendingTags ranks tags by how often they were used within
+// `window` (a Go duration such as 6h, default 24h, capped at a week). Only
+// public chirps from public accounts count, since anyone can see the result.
+func (cfg *apiConfig) handlerGetTrendingTags(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	window := 24 * time.Hour
+	if win := r.URL.Query().Get("window"); win != "" {
+		parsed, err := time.ParseDuration(win)
+		if err != nil || parsed <= 0 {
+			writeJSON(w, 400, errorParameters{Body: "window must be a positive duration like 24h"})
+			return
+		}
+		window = min(parsed, maxTrendingWindow)
+	}
+
+	limit := 10
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 {
+			writeJSON(w, 400, errorParameters{Body: "limit must be a positive integer"})
+			return
+		}
+		limit = min(parsed, pagination.MaxLimit)
+	}
+
+	rows, err := cfg.db.TagsTrending(r.Context(), database.TagsTrendingParams{
+		Since:    time.Now().UTC().Add(-window),
+		RowLimit: int32(limit),
+	})
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Trending Tags: %v", err)})
+		return
+	}
+
+	tags := []trendingTag{}
+	for _, row := range rows {
+		tags = append(tags, trendingTag{Name: row.Name, UseCount: row.UseCount})
+	}
+	writeJSON(w, 200, tags)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/google/uuid"
+)
+
+// Deleted chirps can be restored from the trash for this long.
+const chirpTrashTTL = 30 * 24 * time.Hour
+
+// purgeDeletedChirps empties the trash of chirps deleted more than
+// chirpTrashTTL ago. Chirps with replies become tombstones instead so the
+// threads under them stay intact; their media, revisions, tags, mentions,
+// polls and likes go as if they had been deleted.
+func (cfg *apiConfig) purgeDeletedChirps(ctx context.Context) error {
+	cutoff := time.Now().UTC().Add(-chirpTrashTTL)
+	tx, err := cfg.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	tombstoned, err := qtx.ChirpsTombstoneDeleted(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	if err := qtx.ChirpsStripTombstoned(ctx, tombstoned); err != nil {
+		return err
+	}
+	purged, err := qtx.ChirpsPurgeDeleted(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if len(tombstoned) > 0 || purged > 0 {
+		log.Printf("Purged %d deleted chirps, tombstoned %d", purged, len(tombstoned))
+	}
+	return nil
+}
+
+func (cfg *apiConfig) handlerGetTrash(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	dbChirps, err := cfg.db.ChirpsTrashGet(r.Context(), token_user)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Could not get deleted chirps"})
+		return
+	}
+	chirps, err := cfg.convertDbChirps(r.Context(), token_user, dbChirps)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirps: %v", err)})
+		return
+	}
+	writeJSON(w, 200, chirps)
+}
+
+func (cfg *apiConfig) handlerRestoreChirp(w http.ResponseWriter, r *http.Request) {
+	token_user, ok := cfg.getTokenUser(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+
+	chirpId := r.PathValue("chirpId")
+	chirpUUID, err := uuid.Parse(chirpId)
+	if err != nil {
+		writeJSON(w, 400, errorParameters{Body: fmt.Sprintf("Error Converting chirpId to UUID: %v\nErr: %v", chirpId, err)})
+		return
+	}
+
+	chirp, err := cfg.db.ChirpRestore(r.Context(), database.ChirpRestoreParams{ID: chirpUUID, UserID: token_user})
+	if errors.Is(err, sql.ErrNoRows) {
+		writeJSON(w, 404, errorParameters{Body: "Could not find deleted chirp"})
+		return
+	}
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: "Restoring Chirp Failed!"})
+		return
+	}
+	jsonChirp, err := cfg.convertOneDbChirp(r.Context(), token_user, chirp)
+	if err != nil {
+		writeJSON(w, 500, errorParameters{Body: fmt.Sprintf("Error Getting Chirp: %v", err)})
+		return
+	}
+	writeJSON(w, 200, jsonChirp)
+}
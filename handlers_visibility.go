@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/AkuPython/Chirpy/internal/chirptext"
+	"github.com/AkuPython/Chirpy/internal/database"
+)
+
+// parseVisibility checks the requested visibility of a new chirp, defaulting
+// to public. The read queries enforce it, so this only rejects chirps that
+// could never be shown as asked.
+func parseVisibility(visibility string, body string) (database.ChirpVisibility, error) {
+	switch v := database.ChirpVisibility(visibility); v {
+	case "":
+		return database.ChirpVisibilityPublic, nil
+	case database.ChirpVisibilityPublic, database.ChirpVisibilityUnlisted, database.ChirpVisibilityFollowers:
+		return v, nil
+	case database.ChirpVisibilityDirect:
+		if len(chirptext.Mentions(body)) == 0 {
+			return "", fmt.Errorf("Direct chirps need to mention at least one user")
+		}
+		return v, nil
+	default:
+		return "", fmt.Errorf("Visibility must be public, unlisted, followers or direct")
+	}
+}
+
+// canRechirp reports whether a chirp may be amplified by others. Followers
+// and direct chirps were written for a narrower audience.
+func canRechirp(chirp database.Chirp) bool {
+	return chirp.Visibility == database.ChirpVisibilityPublic || chirp.Visibility == database.ChirpVisibilityUnlisted
+}
+
+// checkEditVisibility checks an edited body against the chirp's visibility.
+// The mentions of a direct chirp are its audience, and they could also read
+// the earlier revisions, so an edit may not change who they are.
+func checkEditVisibility(chirp database.Chirp, body string) error {
+	if _, err := parseVisibility(string(chirp.Visibility), body); err != nil {
+		return err
+	}
+	if chirp.Visibility != database.ChirpVisibilityDirect {
+		return nil
+	}
+	before, after := chirptext.Mentions(chirp.Body), chirptext.Mentions(body)
+	slices.Sort(before)
+	slices.Sort(after)
+	if !slices.Equal(before, after) {
+		return fmt.Errorf("Edits cannot change who a direct chirp mentions")
+	}
+	return nil
+}
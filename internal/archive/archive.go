@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+// Section is one JSON file in the archive. Data is encoded as-is and also
+// shown, pretty-printed, in the HTML index.
+type Section struct {
+	File  string
+	Title string
+	Count int
+	Data  any
+}
+
+type indexSection struct {
+	Section
+	Pretty string
+}
+
+var indexTmpl = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+  <head>
+    <meta charset="utf-8">
+    <title>Chirpy archive for {{.Owner}}</title>
+  </head>
+  <body>
+    <h1>Chirpy archive for {{.Owner}}</h1>
+    <p>Generated {{.Generated.Format "2006-01-02 15:04:05 MST"}}</p>
+    <ul>
+    {{- range .Sections}}
+      <li><a href="{{.File}}">{{.Title}}</a> ({{.Count}})</li>
+    {{- end}}
+    </ul>
+    {{- range .Sections}}
+    <h2>{{.Title}}</h2>
+    <pre>{{.Pretty}}</pre>
+    {{- end}}
+  </body>
+</html>
+`))
+
+// Write streams a zip with one JSON file per section plus an index.html
+// that describes them. Each section is written as soon as it is encoded, but
+// index.html repeats them all, so their JSON is held until the end.
+func Write(w io.Writer, owner string, generated time.Time, sections []Section) error {
+	zw := zip.NewWriter(w)
+
+	index := []indexSection{}
+	for _, section := range sections {
+		dat, err := json.MarshalIndent(section.Data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", section.File, err)
+		}
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: section.File, Method: zip.Deflate, Modified: generated})
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(dat); err != nil {
+			return err
+		}
+		index = append(index, indexSection{Section: section, Pretty: string(dat)})
+	}
+
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: "index.html", Method: zip.Deflate, Modified: generated})
+	if err != nil {
+		return err
+	}
+	err = indexTmpl.Execute(f, struct {
+		Owner     string
+		Generated time.Time
+		Sections  []indexSection
+	}{owner, generated, index})
+	if err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
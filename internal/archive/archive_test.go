@@ -0,0 +1,58 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test archive contents
+func TestWrite(t *testing.T) {
+	buf := bytes.Buffer{}
+	sections := []Section{
+		{File: "profile.json", Title: "Profile", Count: 1, Data: map[string]string{"email": "me@example.com"}},
+		{File: "chirps.json", Title: "Chirps", Count: 1, Data: []string{"<b>hello</b>"}},
+	}
+
+	err := Write(&buf, "me@example.com", time.Now().UTC(), sections)
+	if err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to read archive: %v", err)
+	}
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", f.Name, err)
+		}
+		dat, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(dat)
+	}
+
+	if len(files) != 3 {
+		t.Errorf("Expected 3 files but got %d", len(files))
+	}
+
+	chirps := []string{}
+	if err := json.Unmarshal([]byte(files["chirps.json"]), &chirps); err != nil || chirps[0] != "<b>hello</b>" {
+		t.Errorf("Unexpected chirps.json: %v", files["chirps.json"])
+	}
+
+	// Chirp bodies must not be rendered as markup in the index
+	if strings.Contains(files["index.html"], "<b>hello</b>") {
+		t.Errorf("Expected index.html to escape chirp bodies")
+	}
+	if !strings.Contains(files["index.html"], `href="profile.json"`) {
+		t.Errorf("Expected index.html to link profile.json")
+	}
+}
@@ -0,0 +1,139 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Record is one chirp read from an import file. Index counts records from 1
+// across the whole upload; Err is set when the record could not be decoded.
+type Record struct {
+	Index     int
+	Body      string
+	CreatedAt string
+	Err       error
+}
+
+// exportChirpsFile is the section of an export that holds the chirps.
+const exportChirpsFile = "chirps.json"
+
+// maxLineBytes caps a single JSON Lines record; no chirp comes close.
+const maxLineBytes = 1 << 20
+
+// ErrLineTooLong is the Err of a record whose line is over maxLineBytes.
+// Only that record is rejected.
+var ErrLineTooLong = errors.New("line is too long")
+
+// ErrTooLarge is returned when a zip decompresses to more than the limit
+// given to ReadRecords.
+var ErrTooLarge = errors.New("archive is too large once decompressed")
+
+type rawRecord struct {
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ReadRecords accepts either JSON Lines or a zip archive. Inside a zip,
+// *.jsonl and *.ndjson files are read line by line and *.json files as an
+// array. An archive from the export endpoint is recognised by its
+// chirps.json, and only that file is read since its other sections are not
+// chirps. maxBytes caps the decompressed size of all entries together.
+func ReadRecords(dat []byte, maxBytes int64) ([]Record, error) {
+	if !bytes.HasPrefix(dat, []byte("PK\x03\x04")) {
+		records := []Record{}
+		return readLines(dat, records)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(dat), int64(len(dat)))
+	if err != nil {
+		return nil, fmt.Errorf("reading zip: %w", err)
+	}
+	isExport := false
+	for _, f := range zr.File {
+		if f.Name == exportChirpsFile {
+			isExport = true
+		}
+	}
+
+	records := []Record{}
+	remaining := maxBytes
+	for _, f := range zr.File {
+		if isExport && f.Name != exportChirpsFile {
+			continue
+		}
+		ext := strings.ToLower(path.Ext(f.Name))
+		if ext != ".jsonl" && ext != ".ndjson" && ext != ".json" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", f.Name, err)
+		}
+		// The header's sizes can lie, so count what actually comes out.
+		buf := bytes.Buffer{}
+		n, err := buf.ReadFrom(io.LimitReader(rc, remaining+1))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", f.Name, err)
+		}
+		remaining -= n
+		if remaining < 0 {
+			return nil, ErrTooLarge
+		}
+
+		if ext == ".json" {
+			records, err = readArray(buf.Bytes(), records)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", f.Name, err)
+			}
+		} else {
+			records, err = readLines(buf.Bytes(), records)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", f.Name, err)
+			}
+		}
+	}
+	return records, nil
+}
+
+func readLines(dat []byte, records []Record) ([]Record, error) {
+	for len(dat) > 0 {
+		line := dat
+		if i := bytes.IndexByte(dat, '\n'); i >= 0 {
+			line, dat = dat[:i], dat[i+1:]
+		} else {
+			dat = nil
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if len(line) > maxLineBytes {
+			records = append(records, Record{Index: len(records) + 1, Err: ErrLineTooLong})
+			continue
+		}
+		raw := rawRecord{}
+		err := json.Unmarshal(line, &raw)
+		records = append(records, Record{Index: len(records) + 1, Body: raw.Body, CreatedAt: raw.CreatedAt, Err: err})
+	}
+	return records, nil
+}
+
+func readArray(dat []byte, records []Record) ([]Record, error) {
+	items := []json.RawMessage{}
+	if err := json.Unmarshal(dat, &items); err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		raw := rawRecord{}
+		err := json.Unmarshal(item, &raw)
+		records = append(records, Record{Index: len(records) + 1, Body: raw.Body, CreatedAt: raw.CreatedAt, Err: err})
+	}
+	return records, nil
+}
@@ -0,0 +1,125 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test JSON Lines import
+func TestReadRecordsLines(t *testing.T) {
+	dat := []byte(`{"body": "first", "created_at": "2024-01-02T03:04:05Z"}
+
+not json
+{"body": "third"}
+`)
+	records, err := ReadRecords(dat, 1<<20)
+	if err != nil {
+		t.Fatalf("Failed to read records: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records but got %d", len(records))
+	}
+	if records[0].Body != "first" || records[0].CreatedAt != "2024-01-02T03:04:05Z" || records[0].Err != nil {
+		t.Errorf("Unexpected first record: %+v", records[0])
+	}
+	if records[1].Err == nil {
+		t.Errorf("Expected decode error for record 2, but got nil")
+	}
+	if records[2].Index != 3 || records[2].Body != "third" {
+		t.Errorf("Unexpected third record: %+v", records[2])
+	}
+}
+
+// Test an over-long line rejects only its own record
+func TestReadRecordsLongLine(t *testing.T) {
+	long := `{"body": "` + strings.Repeat("a", maxLineBytes) + `"}`
+	dat := []byte("{\"body\": \"first\"}\n" + long + "\n{\"body\": \"third\"}\n")
+	records, err := ReadRecords(dat, int64(len(dat)))
+	if err != nil {
+		t.Fatalf("Failed to read records: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records but got %d", len(records))
+	}
+	if !errors.Is(records[1].Err, ErrLineTooLong) {
+		t.Errorf("Expected ErrLineTooLong for record 2, got %v", records[1].Err)
+	}
+	if records[2].Index != 3 || records[2].Body != "third" || records[2].Err != nil {
+		t.Errorf("Unexpected third record: %+v", records[2])
+	}
+}
+
+// Test importing an archive written by Write
+func TestReadRecordsExportRoundTrip(t *testing.T) {
+	buf := bytes.Buffer{}
+	chirps := []map[string]string{
+		{"body": "one", "created_at": "2024-01-02T03:04:05Z"},
+		{"body": "two", "created_at": "2024-01-03T03:04:05Z"},
+	}
+	sessions := []map[string]string{
+		{"created_at": "2024-01-04T03:04:05Z", "expires_at": "2024-03-04T03:04:05Z"},
+	}
+	err := Write(&buf, "me@example.com", time.Now().UTC(), []Section{
+		{File: "profile.json", Title: "Profile", Count: 1, Data: map[string]string{"email": "me@example.com"}},
+		{File: "chirps.json", Title: "Chirps", Count: len(chirps), Data: chirps},
+		{File: "sessions.json", Title: "Sessions", Count: len(sessions), Data: sessions},
+	})
+	if err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+
+	records, err := ReadRecords(buf.Bytes(), 1<<20)
+	if err != nil {
+		t.Fatalf("Failed to read records: %v", err)
+	}
+	if len(records) != 2 || records[1].Body != "two" {
+		t.Errorf("Unexpected records: %+v", records)
+	}
+}
+
+// Test zip with JSON Lines inside
+func TestReadRecordsZipLines(t *testing.T) {
+	buf := bytes.Buffer{}
+	zw := zip.NewWriter(&buf)
+	f, _ := zw.Create("tweets.jsonl")
+	f.Write([]byte("{\"body\": \"a\"}\n{\"body\": \"b\"}\n"))
+	f, _ = zw.Create("notes.txt")
+	f.Write([]byte("ignored"))
+	zw.Close()
+
+	records, err := ReadRecords(buf.Bytes(), 1<<20)
+	if err != nil {
+		t.Fatalf("Failed to read records: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected 2 records but got %d", len(records))
+	}
+}
+
+// Test that the decompressed budget covers all entries together
+func TestReadRecordsZipTooLarge(t *testing.T) {
+	buf := bytes.Buffer{}
+	zw := zip.NewWriter(&buf)
+	line := []byte("{\"body\": \"" + strings.Repeat("a", 1000) + "\"}\n")
+	for _, name := range []string{"one.jsonl", "two.jsonl"} {
+		f, _ := zw.Create(name)
+		for i := 0; i < 60; i++ {
+			f.Write(line)
+		}
+	}
+	zw.Close()
+
+	total := int64(2 * 60 * len(line))
+	if _, err := ReadRecords(buf.Bytes(), total); err != nil {
+		t.Fatalf("Failed to read records: %v", err)
+	}
+	// Each file fits on its own, both together do not
+	_, err := ReadRecords(buf.Bytes(), total-1)
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge but got %v", err)
+	}
+}
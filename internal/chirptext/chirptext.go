@@ -0,0 +1,65 @@
+package chirptext
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+var (
+	hashtagRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&#])#([\p{L}\p{N}_]{1,64})`)
+	mentionRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@])@([A-Za-z0-9_]+)`)
+	handleRe  = regexp.MustCompile(`^[a-z0-9_]{3,30}$`)
+)
+
+// Hashtags returns the distinct, lowercased tags in body, in the order
+// they first appear. Tags made only of digits (like "#1") are skipped.
+func Hashtags(body string) []string {
+	return extract(hashtagRe, body, true)
+}
+
+// Mentions returns the distinct, lowercased handles mentioned in body.
+// Unlike tags, handles may be all digits, so "@12345" is a mention. The
+// whole run after the @ is the handle, so one that is too long for a
+// handle is no mention at all rather than a mention of its first part.
+func Mentions(body string) []string {
+	found := []string{}
+	for _, name := range extract(mentionRe, body, false) {
+		if handleRe.MatchString(name) {
+			found = append(found, name)
+		}
+	}
+	return found
+}
+
+// Length counts user-perceived characters (grapheme clusters), so an emoji
+// with skin tone or a flag counts once, however many bytes it takes.
+func Length(body string) int {
+	return uniseg.GraphemeClusterCount(body)
+}
+
+// NormalizeHandle lowercases h, drops a leading @ and checks that what is
+// left is 3-30 letters, digits or underscores.
+func NormalizeHandle(h string) (string, error) {
+	h = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(h), "@"))
+	if !handleRe.MatchString(h) {
+		return "", fmt.Errorf("handle must be 3-30 letters, digits or underscores")
+	}
+	return h, nil
+}
+
+func extract(re *regexp.Regexp, body string, skipDigits bool) []string {
+	seen := make(map[string]bool)
+	found := []string{}
+	for _, match := range re.FindAllStringSubmatch(body, -1) {
+		name := strings.ToLower(match[1])
+		if seen[name] || (skipDigits && strings.Trim(name, "0123456789") == "") {
+			continue
+		}
+		seen[name] = true
+		found = append(found, name)
+	}
+	return found
+}
@@ -0,0 +1,85 @@
+package chirptext
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Test hashtag extraction
+func TestHashtags(t *testing.T) {
+	cases := []struct {
+		body string
+		want []string
+	}{
+		{"no tags here", []string{}},
+		{"#Go is #fun, #go!", []string{"go", "fun"}},
+		{"mid#word and issue #1 are not tags", []string{}},
+		{"unicode #café and #東京", []string{"café", "東京"}},
+		{"(#paren) #snake_case.", []string{"paren", "snake_case"}},
+	}
+
+	for _, c := range cases {
+		got := Hashtags(c.body)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Hashtags(%q) = %v, expected %v", c.body, got, c.want)
+		}
+	}
+}
+
+// Test mention extraction
+func TestMentions(t *testing.T) {
+	cases := []struct {
+		body string
+		want []string
+	}{
+		{"hello @Alice and @bob_99, @alice again", []string{"alice", "bob_99"}},
+		{"mail me at someone@example.com", []string{}},
+		{"@ab is too short", []string{}},
+		{"ping @12345 and #12345", []string{"12345"}},
+		{"@averyveryverylonghandle_over_thirty_chars and @bob", []string{"bob"}},
+	}
+
+	for _, c := range cases {
+		got := Mentions(c.body)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("Mentions(%q) = %v, expected %v", c.body, got, c.want)
+		}
+	}
+}
+
+// Test handle validation
+func TestNormalizeHandle(t *testing.T) {
+	handle, err := NormalizeHandle(" @Chirpy_Fan ")
+	if err != nil {
+		t.Fatalf("Expected valid handle, got error: %v", err)
+	}
+	if handle != "chirpy_fan" {
+		t.Errorf("Expected chirpy_fan but got %v", handle)
+	}
+
+	for _, bad := range []string{"", "ab", "has space", "dash-ed", "waytoolonghandlethatkeepsgoingon"} {
+		if _, err := NormalizeHandle(bad); err == nil {
+			t.Errorf("Expected error for handle %q, but got nil", bad)
+		}
+	}
+}
+
+// Test grapheme cluster length
+func TestLength(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected int
+	}{
+		{"hello", 5},
+		{"héllo", 5},
+		{"こんにちは", 5},
+		{"👍🏽👍🏽", 2},
+		{"🇳🇱!", 2},
+		{"👨‍👩‍👧", 1},
+	}
+	for _, c := range cases {
+		if got := Length(c.input); got != c.expected {
+			t.Errorf("Length(%q) = %d, expected %d", c.input, got, c.expected)
+		}
+	}
+}
@@ -7,25 +7,156 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const chirpAdd = `-- name: ChirpAdd :one
-INSERT INTO chirps (id, created_at, updated_at, body, user_id)
+INSERT INTO chirps (id, created_at, updated_at, body, user_id, parent_id, rechirp_of_id, quoted_chirp_id, publish_at, visibility)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, created_at, updated_at, body, user_id
+RETURNING id, created_at, updated_at, body, user_id, parent_id, tombstoned_at, rechirp_of_id, quoted_chirp_id, search_vector, publish_at, deleted_at, visibility
 `
 
 type ChirpAddParams struct {
-	Body   string
-	UserID uuid.UUID
+	Body          string
+	UserID        uuid.UUID
+	ParentID      uuid.NullUUID
+	RechirpOfID   uuid.NullUUID
+	QuotedChirpID uuid.NullUUID
+	PublishAt     sql.NullTime
+	Visibility    ChirpVisibility
 }
 
 func (q *Queries) ChirpAdd(ctx context.Context, arg ChirpAddParams) (Chirp, error) {
-	row := q.db.QueryRowContext(ctx, chirpAdd, arg.Body, arg.UserID)
+	row := q.db.QueryRowContext(ctx, chirpAdd,
+		arg.Body,
+		arg.UserID,
+		arg.ParentID,
+		arg.RechirpOfID,
+		arg.QuotedChirpID,
+		arg.PublishAt,
+		arg.Visibility,
+	)
+	var i Chirp
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Body,
+		&i.UserID,
+		&i.ParentID,
+		&i.TombstonedAt,
+		&i.RechirpOfID,
+		&i.QuotedChirpID,
+		&i.SearchVector,
+		&i.PublishAt,
+		&i.DeletedAt,
+		&i.Visibility,
+	)
+	return i, err
+}
+
+const chirpGet = `-- name: ChirpGet :one
+SELECT chirps.id, chirps.created_at, chirps.updated_at, chirps.body, chirps.user_id, chirps.parent_id, chirps.tombstoned_at, chirps.rechirp_of_id, chirps.quoted_chirp_id, chirps.search_vector, chirps.publish_at, chirps.deleted_at, chirps.visibility FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirps.id = $1
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_visible_to(chirps, $2::uuid)
+LIMIT 1
+`
+
+type ChirpGetParams struct {
+	ID       uuid.UUID
+	ViewerID uuid.UUID
+}
+
+func (q *Queries) ChirpGet(ctx context.Context, arg ChirpGetParams) (Chirp, error) {
+	row := q.db.QueryRowContext(ctx, chirpGet, arg.ID, arg.ViewerID)
+	var i Chirp
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Body,
+		&i.UserID,
+		&i.ParentID,
+		&i.TombstonedAt,
+		&i.RechirpOfID,
+		&i.QuotedChirpID,
+		&i.SearchVector,
+		&i.PublishAt,
+		&i.DeletedAt,
+		&i.Visibility,
+	)
+	return i, err
+}
+
+const chirpGetListed = `-- name: ChirpGetListed :one
+SELECT chirps.id, chirps.created_at, chirps.updated_at, chirps.body, chirps.user_id, chirps.parent_id, chirps.tombstoned_at, chirps.rechirp_of_id, chirps.quoted_chirp_id, chirps.search_vector, chirps.publish_at, chirps.deleted_at, chirps.visibility FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirps.id = $1
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_listed_to(chirps, $2::uuid)
+LIMIT 1
+`
+
+type ChirpGetListedParams struct {
+	ID       uuid.UUID
+	ViewerID uuid.UUID
+}
+
+func (q *Queries) ChirpGetListed(ctx context.Context, arg ChirpGetListedParams) (Chirp, error) {
+	row := q.db.QueryRowContext(ctx, chirpGetListed, arg.ID, arg.ViewerID)
+	var i Chirp
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Body,
+		&i.UserID,
+		&i.ParentID,
+		&i.TombstonedAt,
+		&i.RechirpOfID,
+		&i.QuotedChirpID,
+		&i.SearchVector,
+		&i.PublishAt,
+		&i.DeletedAt,
+		&i.Visibility,
+	)
+	return i, err
+}
+
+const chirpImport = `-- name: ChirpImport :one
+INSERT INTO chirps (id, created_at, updated_at, body, user_id)
+SELECT gen_random_uuid(), $1::timestamp, $1::timestamp, $2::text, $3::uuid
+WHERE NOT EXISTS (
+    SELECT 1 FROM chirps
+    WHERE user_id = $3::uuid
+      AND created_at = $1::timestamp
+      AND body = $2::text
+)
+RETURNING id, created_at, updated_at, body, user_id, parent_id, tombstoned_at, rechirp_of_id, quoted_chirp_id, search_vector, publish_at, deleted_at, visibility
+`
+
+type ChirpImportParams struct {
+	CreatedAt time.Time
+	Body      string
+	UserID    uuid.UUID
+}
+
+func (q *Queries) ChirpImport(ctx context.Context, arg ChirpImportParams) (Chirp, error) {
+	row := q.db.QueryRowContext(ctx, chirpImport, arg.CreatedAt, arg.Body, arg.UserID)
 	var i Chirp
 	err := row.Scan(
 		&i.ID,
@@ -33,27 +164,183 @@ func (q *Queries) ChirpAdd(ctx context.Context, arg ChirpAddParams) (Chirp, erro
 		&i.UpdatedAt,
 		&i.Body,
 		&i.UserID,
+		&i.ParentID,
+		&i.TombstonedAt,
+		&i.RechirpOfID,
+		&i.QuotedChirpID,
+		&i.SearchVector,
+		&i.PublishAt,
+		&i.DeletedAt,
+		&i.Visibility,
 	)
 	return i, err
 }
 
-const chirpDelete = `-- name: ChirpDelete :exec
-DELETE FROM chirps
+const chirpReplyCounts = `-- name: ChirpReplyCounts :many
+SELECT chirps.parent_id::uuid AS chirp_id, COUNT(*) AS reply_count
+FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirps.parent_id = ANY($1::uuid[])
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_visible_to(chirps, $2::uuid)
+GROUP BY chirps.parent_id
+`
+
+type ChirpReplyCountsParams struct {
+	ChirpIds []uuid.UUID
+	ViewerID uuid.UUID
+}
+
+type ChirpReplyCountsRow struct {
+	ChirpID    uuid.UUID
+	ReplyCount int64
+}
+
+func (q *Queries) ChirpReplyCounts(ctx context.Context, arg ChirpReplyCountsParams) ([]ChirpReplyCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, chirpReplyCounts, pq.Array(arg.ChirpIds), arg.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChirpReplyCountsRow
+	for rows.Next() {
+		var i ChirpReplyCountsRow
+		if err := rows.Scan(&i.ChirpID, &i.ReplyCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const chirpSoftDelete = `-- name: ChirpSoftDelete :exec
+UPDATE chirps
+SET deleted_at = NOW()
 WHERE id = $1
 `
 
-func (q *Queries) ChirpDelete(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, chirpDelete, id)
+func (q *Queries) ChirpSoftDelete(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, chirpSoftDelete, id)
 	return err
 }
 
-const chirpGet = `-- name: ChirpGet :one
-SELECT id, created_at, updated_at, body, user_id FROM chirps
-WHERE id = $1 LIMIT 1
+const chirpThread = `-- name: ChirpThread :many
+WITH RECURSIVE ancestors AS (
+    SELECT chirps.id, chirps.parent_id
+    FROM chirps
+    WHERE chirps.id = $2
+    UNION ALL
+    SELECT c.id, c.parent_id
+    FROM chirps c
+    JOIN ancestors a ON c.id = a.parent_id
+), thread AS (
+    SELECT chirps.id, 0::int AS depth
+    FROM chirps
+    WHERE chirps.id = (SELECT ancestors.id FROM ancestors WHERE ancestors.parent_id IS NULL)
+    UNION ALL
+    SELECT c.id, t.depth + 1
+    FROM chirps c
+    JOIN thread t ON c.parent_id = t.id
+)
+SELECT chirps.id, chirps.created_at, chirps.updated_at, chirps.body, chirps.user_id, chirps.parent_id, chirps.tombstoned_at, chirps.rechirp_of_id, chirps.quoted_chirp_id, chirps.search_vector, chirps.publish_at, chirps.deleted_at, chirps.visibility, thread.depth FROM thread
+JOIN chirps ON chirps.id = thread.id
+LEFT JOIN users authors ON authors.id = chirps.user_id
+WHERE authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirp_visible_to(chirps, $1::uuid)
+ORDER BY thread.depth, chirps.created_at, chirps.id
+`
+
+type ChirpThreadParams struct {
+	ViewerID uuid.UUID
+	ID       uuid.UUID
+}
+
+type ChirpThreadRow struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Body          string
+	UserID        uuid.UUID
+	ParentID      uuid.NullUUID
+	TombstonedAt  sql.NullTime
+	RechirpOfID   uuid.NullUUID
+	QuotedChirpID uuid.NullUUID
+	SearchVector  interface{}
+	PublishAt     sql.NullTime
+	DeletedAt     sql.NullTime
+	Visibility    ChirpVisibility
+	Depth         int32
+}
+
+func (q *Queries) ChirpThread(ctx context.Context, arg ChirpThreadParams) ([]ChirpThreadRow, error) {
+	rows, err := q.db.QueryContext(ctx, chirpThread, arg.ViewerID, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChirpThreadRow
+	for rows.Next() {
+		var i ChirpThreadRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Body,
+			&i.UserID,
+			&i.ParentID,
+			&i.TombstonedAt,
+			&i.RechirpOfID,
+			&i.QuotedChirpID,
+			&i.SearchVector,
+			&i.PublishAt,
+			&i.DeletedAt,
+			&i.Visibility,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const chirpUpdate = `-- name: ChirpUpdate :one
+WITH revision AS (
+    INSERT INTO chirp_revisions (id, created_at, chirp_id, body)
+    SELECT gen_random_uuid(), NOW(), chirps.id, chirps.body
+    FROM chirps
+    WHERE chirps.id = $1
+)
+UPDATE chirps
+SET updated_at = NOW(),
+    body = $2
+WHERE chirps.id = $1
+RETURNING id, created_at, updated_at, body, user_id, parent_id, tombstoned_at, rechirp_of_id, quoted_chirp_id, search_vector, publish_at, deleted_at, visibility
 `
 
-func (q *Queries) ChirpGet(ctx context.Context, id uuid.UUID) (Chirp, error) {
-	row := q.db.QueryRowContext(ctx, chirpGet, id)
+type ChirpUpdateParams struct {
+	ID   uuid.UUID
+	Body string
+}
+
+func (q *Queries) ChirpUpdate(ctx context.Context, arg ChirpUpdateParams) (Chirp, error) {
+	row := q.db.QueryRowContext(ctx, chirpUpdate, arg.ID, arg.Body)
 	var i Chirp
 	err := row.Scan(
 		&i.ID,
@@ -61,18 +348,104 @@ func (q *Queries) ChirpGet(ctx context.Context, id uuid.UUID) (Chirp, error) {
 		&i.UpdatedAt,
 		&i.Body,
 		&i.UserID,
+		&i.ParentID,
+		&i.TombstonedAt,
+		&i.RechirpOfID,
+		&i.QuotedChirpID,
+		&i.SearchVector,
+		&i.PublishAt,
+		&i.DeletedAt,
+		&i.Visibility,
 	)
 	return i, err
 }
 
+const chirpsByUser = `-- name: ChirpsByUser :many
+SELECT id, created_at, updated_at, body, user_id, parent_id, tombstoned_at, rechirp_of_id, quoted_chirp_id, search_vector, publish_at, deleted_at, visibility FROM chirps
+WHERE user_id = $1
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ChirpsByUser(ctx context.Context, userID uuid.UUID) ([]Chirp, error) {
+	rows, err := q.db.QueryContext(ctx, chirpsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Chirp
+	for rows.Next() {
+		var i Chirp
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Body,
+			&i.UserID,
+			&i.ParentID,
+			&i.TombstonedAt,
+			&i.RechirpOfID,
+			&i.QuotedChirpID,
+			&i.SearchVector,
+			&i.PublishAt,
+			&i.DeletedAt,
+			&i.Visibility,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const chirpsGet = `-- name: ChirpsGet :many
-SELECT id, created_at, updated_at, body, user_id FROM chirps
-WHERE (COALESCE($1::uuid, '00000000-0000-0000-0000-000000000000') = '00000000-0000-0000-0000-000000000000' OR user_id = $1)
-ORDER BY created_at ASC
+SELECT chirps.id, chirps.created_at, chirps.updated_at, chirps.body, chirps.user_id, chirps.parent_id, chirps.tombstoned_at, chirps.rechirp_of_id, chirps.quoted_chirp_id, chirps.search_vector, chirps.publish_at, chirps.deleted_at, chirps.visibility FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE ($1::uuid = '00000000-0000-0000-0000-000000000000' OR chirps.user_id = $1)
+  AND ($1::uuid = '00000000-0000-0000-0000-000000000000' OR authors.pinned_chirp_id IS DISTINCT FROM chirps.id)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_listed_to(chirps, $2::uuid)
+  AND (
+    NOT $3::boolean
+    OR ($4::boolean AND (chirps.created_at, chirps.id) < ($5::timestamp, $6::uuid))
+    OR (NOT $4::boolean AND (chirps.created_at, chirps.id) > ($5::timestamp, $6::uuid))
+  )
+ORDER BY
+    CASE WHEN $4::boolean THEN chirps.created_at END DESC,
+    CASE WHEN $4::boolean THEN chirps.id END DESC,
+    chirps.created_at ASC,
+    chirps.id ASC
+LIMIT $7
 `
 
-func (q *Queries) ChirpsGet(ctx context.Context, dollar_1 uuid.UUID) ([]Chirp, error) {
-	rows, err := q.db.QueryContext(ctx, chirpsGet, dollar_1)
+type ChirpsGetParams struct {
+	AuthorID        uuid.UUID
+	ViewerID        uuid.UUID
+	HasCursor       bool
+	SortDesc        bool
+	CursorCreatedAt time.Time
+	CursorID        uuid.UUID
+	RowLimit        int32
+}
+
+func (q *Queries) ChirpsGet(ctx context.Context, arg ChirpsGetParams) ([]Chirp, error) {
+	rows, err := q.db.QueryContext(ctx, chirpsGet,
+		arg.AuthorID,
+		arg.ViewerID,
+		arg.HasCursor,
+		arg.SortDesc,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -86,6 +459,14 @@ func (q *Queries) ChirpsGet(ctx context.Context, dollar_1 uuid.UUID) ([]Chirp, e
 			&i.UpdatedAt,
 			&i.Body,
 			&i.UserID,
+			&i.ParentID,
+			&i.TombstonedAt,
+			&i.RechirpOfID,
+			&i.QuotedChirpID,
+			&i.SearchVector,
+			&i.PublishAt,
+			&i.DeletedAt,
+			&i.Visibility,
 		); err != nil {
 			return nil, err
 		}
@@ -99,3 +480,157 @@ func (q *Queries) ChirpsGet(ctx context.Context, dollar_1 uuid.UUID) ([]Chirp, e
 	}
 	return items, nil
 }
+
+const chirpsGetByIDs = `-- name: ChirpsGetByIDs :many
+SELECT chirps.id, chirps.created_at, chirps.updated_at, chirps.body, chirps.user_id, chirps.parent_id, chirps.tombstoned_at, chirps.rechirp_of_id, chirps.quoted_chirp_id, chirps.search_vector, chirps.publish_at, chirps.deleted_at, chirps.visibility FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirps.id = ANY($1::uuid[])
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_visible_to(chirps, $2::uuid)
+`
+
+type ChirpsGetByIDsParams struct {
+	Ids      []uuid.UUID
+	ViewerID uuid.UUID
+}
+
+func (q *Queries) ChirpsGetByIDs(ctx context.Context, arg ChirpsGetByIDsParams) ([]Chirp, error) {
+	rows, err := q.db.QueryContext(ctx, chirpsGetByIDs, pq.Array(arg.Ids), arg.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Chirp
+	for rows.Next() {
+		var i Chirp
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Body,
+			&i.UserID,
+			&i.ParentID,
+			&i.TombstonedAt,
+			&i.RechirpOfID,
+			&i.QuotedChirpID,
+			&i.SearchVector,
+			&i.PublishAt,
+			&i.DeletedAt,
+			&i.Visibility,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const chirpsGetByLikes = `-- name: ChirpsGetByLikes :many
+SELECT chirps.id, chirps.created_at, chirps.updated_at, chirps.body, chirps.user_id, chirps.parent_id, chirps.tombstoned_at, chirps.rechirp_of_id, chirps.quoted_chirp_id, chirps.search_vector, chirps.publish_at, chirps.deleted_at, chirps.visibility, COUNT(chirp_likes.user_id) AS like_count
+FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+LEFT JOIN chirp_likes ON chirp_likes.chirp_id = chirps.id
+WHERE ($1::uuid = '00000000-0000-0000-0000-000000000000' OR chirps.user_id = $1)
+  AND ($1::uuid = '00000000-0000-0000-0000-000000000000' OR authors.pinned_chirp_id IS DISTINCT FROM chirps.id)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_listed_to(chirps, $2::uuid)
+GROUP BY chirps.id
+HAVING NOT $3::boolean
+    OR (COUNT(chirp_likes.user_id), chirps.created_at, chirps.id) < ($4::bigint, $5::timestamp, $6::uuid)
+ORDER BY like_count DESC, chirps.created_at DESC, chirps.id DESC
+LIMIT $7
+`
+
+type ChirpsGetByLikesParams struct {
+	AuthorID        uuid.UUID
+	ViewerID        uuid.UUID
+	HasCursor       bool
+	CursorLikes     int64
+	CursorCreatedAt time.Time
+	CursorID        uuid.UUID
+	RowLimit        int32
+}
+
+type ChirpsGetByLikesRow struct {
+	Chirp     Chirp
+	LikeCount int64
+}
+
+func (q *Queries) ChirpsGetByLikes(ctx context.Context, arg ChirpsGetByLikesParams) ([]ChirpsGetByLikesRow, error) {
+	rows, err := q.db.QueryContext(ctx, chirpsGetByLikes,
+		arg.AuthorID,
+		arg.ViewerID,
+		arg.HasCursor,
+		arg.CursorLikes,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChirpsGetByLikesRow
+	for rows.Next() {
+		var i ChirpsGetByLikesRow
+		if err := rows.Scan(
+			&i.Chirp.ID,
+			&i.Chirp.CreatedAt,
+			&i.Chirp.UpdatedAt,
+			&i.Chirp.Body,
+			&i.Chirp.UserID,
+			&i.Chirp.ParentID,
+			&i.Chirp.TombstonedAt,
+			&i.Chirp.RechirpOfID,
+			&i.Chirp.QuotedChirpID,
+			&i.Chirp.SearchVector,
+			&i.Chirp.PublishAt,
+			&i.Chirp.DeletedAt,
+			&i.Chirp.Visibility,
+			&i.LikeCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const userChirpCount = `-- name: UserChirpCount :one
+SELECT COUNT(*) FROM chirps
+WHERE user_id = $1
+  AND tombstoned_at IS NULL
+  AND deleted_at IS NULL
+  AND publish_at IS NULL
+  AND chirp_visible_to(chirps, $2::uuid)
+`
+
+type UserChirpCountParams struct {
+	UserID   uuid.UUID
+	ViewerID uuid.UUID
+}
+
+func (q *Queries) UserChirpCount(ctx context.Context, arg UserChirpCountParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, userChirpCount, arg.UserID, arg.ViewerID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
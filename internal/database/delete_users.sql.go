@@ -9,6 +9,16 @@ import (
 	"context"
 )
 
+const deleteAuthorlessChirps = `-- name: DeleteAuthorlessChirps :exec
+DELETE FROM chirps
+WHERE user_id IS NULL
+`
+
+func (q *Queries) DeleteAuthorlessChirps(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteAuthorlessChirps)
+	return err
+}
+
 const deleteUsers = `-- name: DeleteUsers :exec
 DELETE FROM users
 `
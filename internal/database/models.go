@@ -6,17 +6,173 @@ package database
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type ChirpVisibility string
+
+const (
+	ChirpVisibilityPublic    ChirpVisibility = "public"
+	ChirpVisibilityUnlisted  ChirpVisibility = "unlisted"
+	ChirpVisibilityFollowers ChirpVisibility = "followers"
+	ChirpVisibilityDirect    ChirpVisibility = "direct"
+)
+
+func (e *ChirpVisibility) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ChirpVisibility(s)
+	case string:
+		*e = ChirpVisibility(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ChirpVisibility: %T", src)
+	}
+	return nil
+}
+
+type NullChirpVisibility struct {
+	ChirpVisibility ChirpVisibility
+	Valid           bool // Valid is true if ChirpVisibility is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullChirpVisibility) Scan(value interface{}) error {
+	if value == nil {
+		ns.ChirpVisibility, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ChirpVisibility.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullChirpVisibility) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ChirpVisibility), nil
+}
+
+type Block struct {
+	BlockerID uuid.UUID
+	BlockedID uuid.UUID
+	CreatedAt time.Time
+}
+
 type Chirp struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Body          string
+	UserID        uuid.UUID
+	ParentID      uuid.NullUUID
+	TombstonedAt  sql.NullTime
+	RechirpOfID   uuid.NullUUID
+	QuotedChirpID uuid.NullUUID
+	SearchVector  interface{}
+	PublishAt     sql.NullTime
+	DeletedAt     sql.NullTime
+	Visibility    ChirpVisibility
+}
+
+type ChirpBookmark struct {
+	ChirpID   uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+type ChirpFilterHit struct {
+	ChirpID   uuid.UUID
+	Rule      string
+	CreatedAt time.Time
+}
+
+type ChirpLike struct {
+	ChirpID   uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+type ChirpRevision struct {
 	ID        uuid.UUID
 	CreatedAt time.Time
-	UpdatedAt time.Time
+	ChirpID   uuid.UUID
 	Body      string
+}
+
+type ChirpTag struct {
+	ChirpID   uuid.UUID
+	TagID     uuid.UUID
+	CreatedAt time.Time
+}
+
+type Follow struct {
+	FollowerID uuid.UUID
+	FolloweeID uuid.UUID
+	CreatedAt  time.Time
+}
+
+type FollowRequest struct {
+	RequesterID uuid.UUID
+	TargetID    uuid.UUID
+	CreatedAt   time.Time
+}
+
+type MediaFile struct {
+	ID          uuid.UUID
+	CreatedAt   time.Time
+	UserID      uuid.NullUUID
+	ChirpID     uuid.NullUUID
+	Position    int32
+	ContentType string
+	Width       int32
+	Height      int32
+	SizeBytes   int64
+	StorageKey  string
+	ThumbKey    string
+	ThumbWidth  int32
+	ThumbHeight int32
+}
+
+type Mention struct {
+	ChirpID   uuid.UUID
 	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+type Mute struct {
+	MuterID   uuid.UUID
+	MutedID   uuid.UUID
+	CreatedAt time.Time
+}
+
+type Poll struct {
+	ChirpID   uuid.UUID
+	CreatedAt time.Time
+	ClosesAt  time.Time
+}
+
+type PollOption struct {
+	ID       uuid.UUID
+	ChirpID  uuid.UUID
+	Position int32
+	Label    string
+}
+
+type PollVote struct {
+	ChirpID   uuid.UUID
+	UserID    uuid.UUID
+	OptionID  uuid.UUID
+	CreatedAt time.Time
+}
+
+type ProfanityWord struct {
+	Word      string
+	CreatedAt time.Time
 }
 
 type RefreshToken struct {
@@ -28,11 +184,24 @@ type RefreshToken struct {
 	RevokedAt sql.NullTime
 }
 
+type Tag struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+	Name      string
+}
+
 type User struct {
-	ID             uuid.UUID
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	Email          string
-	HashedPassword string
-	IsChirpyRed    bool
+	ID                  uuid.UUID
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	Email               string
+	HashedPassword      string
+	IsChirpyRed         bool
+	Handle              sql.NullString
+	DisplayName         sql.NullString
+	Bio                 sql.NullString
+	AvatarUrl           sql.NullString
+	DeletionRequestedAt sql.NullTime
+	PinnedChirpID       uuid.NullUUID
+	IsPrivate           bool
 }
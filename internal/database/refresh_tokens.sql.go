@@ -7,6 +7,7 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -63,3 +64,57 @@ func (q *Queries) RefreshTokenRevoke(ctx context.Context, token string) error {
 	_, err := q.db.ExecContext(ctx, refreshTokenRevoke, token)
 	return err
 }
+
+const refreshTokenSessionsForUser = `-- name: RefreshTokenSessionsForUser :many
+SELECT created_at, updated_at, expires_at, revoked_at FROM refresh_tokens
+WHERE user_id = $1
+ORDER BY created_at ASC
+`
+
+type RefreshTokenSessionsForUserRow struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+	RevokedAt sql.NullTime
+}
+
+func (q *Queries) RefreshTokenSessionsForUser(ctx context.Context, userID uuid.UUID) ([]RefreshTokenSessionsForUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, refreshTokenSessionsForUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RefreshTokenSessionsForUserRow
+	for rows.Next() {
+		var i RefreshTokenSessionsForUserRow
+		if err := rows.Scan(
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const refreshTokensRevokeForUser = `-- name: RefreshTokensRevokeForUser :exec
+UPDATE refresh_tokens
+SET updated_at = NOW(),
+    revoked_at = NOW()
+WHERE user_id = $1
+  AND revoked_at IS NULL
+`
+
+func (q *Queries) RefreshTokensRevokeForUser(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, refreshTokensRevokeForUser, userID)
+	return err
+}
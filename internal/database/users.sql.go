@@ -7,22 +7,67 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const chirpsTombstonePurgedUsers = `-- name: ChirpsTombstonePurgedUsers :many
+UPDATE chirps
+SET updated_at = NOW(),
+    body = '',
+    quoted_chirp_id = NULL,
+    tombstoned_at = COALESCE(tombstoned_at, NOW()),
+    deleted_at = NULL,
+    user_id = NULL
+WHERE user_id IN (
+    SELECT users.id FROM users
+    WHERE users.deletion_requested_at < $1::timestamp
+    FOR UPDATE
+)
+  AND EXISTS (
+    SELECT 1 FROM chirps replies
+    WHERE replies.parent_id = chirps.id
+  )
+RETURNING id
+`
+
+func (q *Queries) ChirpsTombstonePurgedUsers(ctx context.Context, cutoff time.Time) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, chirpsTombstonePurgedUsers, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (id, created_at, updated_at, email, hashed_password)
+INSERT INTO users (id, created_at, updated_at, email, hashed_password, handle)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3
 )
-RETURNING id, created_at, updated_at, email, is_chirpy_red
+RETURNING id, created_at, updated_at, email, is_chirpy_red, handle
 `
 
 type CreateUserParams struct {
 	Email          string
 	HashedPassword string
+	Handle         sql.NullString
 }
 
 type CreateUserRow struct {
@@ -31,10 +76,11 @@ type CreateUserRow struct {
 	UpdatedAt   time.Time
 	Email       string
 	IsChirpyRed bool
+	Handle      sql.NullString
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error) {
-	row := q.db.QueryRowContext(ctx, createUser, arg.Email, arg.HashedPassword)
+	row := q.db.QueryRowContext(ctx, createUser, arg.Email, arg.HashedPassword, arg.Handle)
 	var i CreateUserRow
 	err := row.Scan(
 		&i.ID,
@@ -42,12 +88,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		&i.UpdatedAt,
 		&i.Email,
 		&i.IsChirpyRed,
+		&i.Handle,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, created_at, updated_at, email, hashed_password, is_chirpy_red FROM users
+SELECT id, created_at, updated_at, email, hashed_password, is_chirpy_red, handle, display_name, bio, avatar_url, deletion_requested_at, pinned_chirp_id, is_private FROM users
 WHERE email = $1
 `
 
@@ -61,6 +108,121 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Email,
 		&i.HashedPassword,
 		&i.IsChirpyRed,
+		&i.Handle,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.DeletionRequestedAt,
+		&i.PinnedChirpID,
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const getUserByHandle = `-- name: GetUserByHandle :one
+SELECT id, created_at, updated_at, email, hashed_password, is_chirpy_red, handle, display_name, bio, avatar_url, deletion_requested_at, pinned_chirp_id, is_private FROM users
+WHERE handle = $1::text
+  AND deletion_requested_at IS NULL
+`
+
+func (q *Queries) GetUserByHandle(ctx context.Context, handle string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByHandle, handle)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Email,
+		&i.HashedPassword,
+		&i.IsChirpyRed,
+		&i.Handle,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.DeletionRequestedAt,
+		&i.PinnedChirpID,
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, created_at, updated_at, email, hashed_password, is_chirpy_red, handle, display_name, bio, avatar_url, deletion_requested_at, pinned_chirp_id, is_private FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Email,
+		&i.HashedPassword,
+		&i.IsChirpyRed,
+		&i.Handle,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.DeletionRequestedAt,
+		&i.PinnedChirpID,
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const patchUser = `-- name: PatchUser :one
+UPDATE users
+SET updated_at = NOW(),
+    email = COALESCE($1::text, email),
+    hashed_password = COALESCE($2::text, hashed_password),
+    handle = COALESCE($3::text, handle),
+    display_name = COALESCE($4::text, display_name),
+    bio = COALESCE($5::text, bio),
+    avatar_url = COALESCE($6::text, avatar_url),
+    is_private = COALESCE($7::boolean, is_private)
+WHERE id = $8
+RETURNING id, created_at, updated_at, email, hashed_password, is_chirpy_red, handle, display_name, bio, avatar_url, deletion_requested_at, pinned_chirp_id, is_private
+`
+
+type PatchUserParams struct {
+	Email          sql.NullString
+	HashedPassword sql.NullString
+	Handle         sql.NullString
+	DisplayName    sql.NullString
+	Bio            sql.NullString
+	AvatarUrl      sql.NullString
+	IsPrivate      sql.NullBool
+	ID             uuid.UUID
+}
+
+func (q *Queries) PatchUser(ctx context.Context, arg PatchUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, patchUser,
+		arg.Email,
+		arg.HashedPassword,
+		arg.Handle,
+		arg.DisplayName,
+		arg.Bio,
+		arg.AvatarUrl,
+		arg.IsPrivate,
+		arg.ID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Email,
+		&i.HashedPassword,
+		&i.IsChirpyRed,
+		&i.Handle,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.DeletionRequestedAt,
+		&i.PinnedChirpID,
+		&i.IsPrivate,
 	)
 	return i, err
 }
@@ -68,20 +230,36 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 const updateOneUser = `-- name: UpdateOneUser :one
 UPDATE users
 SET updated_at = NOW(),
-    email = $2,
-    hashed_password = $3
-WHERE id = $1
-RETURNING id, created_at, updated_at, email, hashed_password, is_chirpy_red
+    email = $1,
+    hashed_password = $2,
+    handle = COALESCE($3::text, handle),
+    display_name = COALESCE($4::text, display_name),
+    bio = COALESCE($5::text, bio),
+    avatar_url = COALESCE($6::text, avatar_url)
+WHERE id = $7
+RETURNING id, created_at, updated_at, email, hashed_password, is_chirpy_red, handle, display_name, bio, avatar_url, deletion_requested_at, pinned_chirp_id, is_private
 `
 
 type UpdateOneUserParams struct {
-	ID             uuid.UUID
 	Email          string
 	HashedPassword string
+	Handle         sql.NullString
+	DisplayName    sql.NullString
+	Bio            sql.NullString
+	AvatarUrl      sql.NullString
+	ID             uuid.UUID
 }
 
 func (q *Queries) UpdateOneUser(ctx context.Context, arg UpdateOneUserParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, updateOneUser, arg.ID, arg.Email, arg.HashedPassword)
+	row := q.db.QueryRowContext(ctx, updateOneUser,
+		arg.Email,
+		arg.HashedPassword,
+		arg.Handle,
+		arg.DisplayName,
+		arg.Bio,
+		arg.AvatarUrl,
+		arg.ID,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -90,6 +268,13 @@ func (q *Queries) UpdateOneUser(ctx context.Context, arg UpdateOneUserParams) (U
 		&i.Email,
 		&i.HashedPassword,
 		&i.IsChirpyRed,
+		&i.Handle,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.DeletionRequestedAt,
+		&i.PinnedChirpID,
+		&i.IsPrivate,
 	)
 	return i, err
 }
@@ -98,7 +283,7 @@ const updateUserRed = `-- name: UpdateUserRed :one
 UPDATE users
 SET is_chirpy_red = TRUE
 WHERE id = $1
-RETURNING id, created_at, updated_at, email, hashed_password, is_chirpy_red
+RETURNING id, created_at, updated_at, email, hashed_password, is_chirpy_red, handle, display_name, bio, avatar_url, deletion_requested_at, pinned_chirp_id, is_private
 `
 
 func (q *Queries) UpdateUserRed(ctx context.Context, id uuid.UUID) (User, error) {
@@ -111,6 +296,84 @@ func (q *Queries) UpdateUserRed(ctx context.Context, id uuid.UUID) (User, error)
 		&i.Email,
 		&i.HashedPassword,
 		&i.IsChirpyRed,
+		&i.Handle,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.DeletionRequestedAt,
+		&i.PinnedChirpID,
+		&i.IsPrivate,
+	)
+	return i, err
+}
+
+const userCancelDeletion = `-- name: UserCancelDeletion :exec
+UPDATE users
+SET updated_at = NOW(),
+    deletion_requested_at = NULL
+WHERE id = $1
+`
+
+func (q *Queries) UserCancelDeletion(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, userCancelDeletion, id)
+	return err
+}
+
+const userRequestDeletion = `-- name: UserRequestDeletion :one
+UPDATE users
+SET updated_at = NOW(),
+    deletion_requested_at = NOW()
+WHERE id = $1
+RETURNING id, created_at, updated_at, email, hashed_password, is_chirpy_red, handle, display_name, bio, avatar_url, deletion_requested_at, pinned_chirp_id, is_private
+`
+
+func (q *Queries) UserRequestDeletion(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, userRequestDeletion, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Email,
+		&i.HashedPassword,
+		&i.IsChirpyRed,
+		&i.Handle,
+		&i.DisplayName,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.DeletionRequestedAt,
+		&i.PinnedChirpID,
+		&i.IsPrivate,
 	)
 	return i, err
 }
+
+const userSetPinnedChirp = `-- name: UserSetPinnedChirp :exec
+UPDATE users
+SET updated_at = NOW(),
+    pinned_chirp_id = $2
+WHERE id = $1
+`
+
+type UserSetPinnedChirpParams struct {
+	ID            uuid.UUID
+	PinnedChirpID uuid.NullUUID
+}
+
+func (q *Queries) UserSetPinnedChirp(ctx context.Context, arg UserSetPinnedChirpParams) error {
+	_, err := q.db.ExecContext(ctx, userSetPinnedChirp, arg.ID, arg.PinnedChirpID)
+	return err
+}
+
+const usersPurgeDeleted = `-- name: UsersPurgeDeleted :execrows
+DELETE FROM users
+WHERE deletion_requested_at < $1::timestamp
+`
+
+func (q *Queries) UsersPurgeDeleted(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, usersPurgeDeleted, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,56 @@
+package media
+
+import (
+	"errors"
+)
+
+var errGIFTruncated = errors.New("gif is truncated")
+
+// gifFrameCount walks the block structure of a GIF and counts its frames
+// without decompressing any of them, so an animation can be sized up before
+// gif.DecodeAll allocates every frame.
+func gifFrameCount(dat []byte) (int, error) {
+	// Header and logical screen descriptor
+	if len(dat) < 13 {
+		return 0, errGIFTruncated
+	}
+	i := 13
+	if dat[10]&0x80 != 0 {
+		i += 3 << (dat[10]&0x07 + 1)
+	}
+
+	frames := 0
+	for i < len(dat) {
+		switch dat[i] {
+		case 0x21: // extension: label, then sub-blocks
+			i += 2
+		case 0x2C: // image descriptor, optional local color table, LZW code size
+			if i+10 > len(dat) {
+				return 0, errGIFTruncated
+			}
+			packed := dat[i+9]
+			i += 10
+			if packed&0x80 != 0 {
+				i += 3 << (packed&0x07 + 1)
+			}
+			i++
+			frames++
+		case 0x3B: // trailer
+			return frames, nil
+		default:
+			return 0, errors.New("gif has an unknown block")
+		}
+		for {
+			if i >= len(dat) {
+				return 0, errGIFTruncated
+			}
+			size := int(dat[i])
+			i += 1 + size
+			if size == 0 {
+				break
+			}
+		}
+	}
+	// gif.DecodeAll reports the missing trailer.
+	return frames, nil
+}
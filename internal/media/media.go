@@ -0,0 +1,143 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	MaxBytes = 10 << 20
+	// MaxPixels guards against small files that decode to huge images. For
+	// animations it covers all frames together.
+	MaxPixels = 40_000_000
+	ThumbSize = 320
+)
+
+var ErrUnsupported = errors.New("unsupported image type")
+
+// Image is an upload after processing. Data has been re-encoded from the
+// decoded pixels, which drops EXIF and any other metadata the original
+// carried. JPEGs are turned upright first, so Width and Height are as shown.
+type Image struct {
+	ContentType string
+	Ext         string
+	Data        []byte
+	Width       int
+	Height      int
+
+	ThumbContentType string
+	ThumbExt         string
+	Thumb            []byte
+	ThumbWidth       int
+	ThumbHeight      int
+}
+
+// Process validates an uploaded image, strips its metadata and renders a
+// thumbnail that fits in ThumbSize x ThumbSize. The type is sniffed from the
+// data; whatever the client claimed is ignored.
+func Process(dat []byte) (Image, error) {
+	if len(dat) > MaxBytes {
+		return Image{}, fmt.Errorf("image is larger than %d bytes", MaxBytes)
+	}
+	contentType := http.DetectContentType(dat)
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/gif" {
+		return Image{}, ErrUnsupported
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(dat))
+	if err != nil {
+		return Image{}, fmt.Errorf("reading image: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxPixels {
+		return Image{}, fmt.Errorf("image must be at most %d pixels", MaxPixels)
+	}
+
+	img := Image{ContentType: contentType, Width: config.Width, Height: config.Height}
+	buf := bytes.Buffer{}
+	var first image.Image
+	switch contentType {
+	case "image/jpeg":
+		decoded, err := jpeg.Decode(bytes.NewReader(dat))
+		if err != nil {
+			return Image{}, fmt.Errorf("decoding jpeg: %w", err)
+		}
+		src := orient(decoded, jpegOrientation(dat))
+		img.Width, img.Height = src.Bounds().Dx(), src.Bounds().Dy()
+		err = jpeg.Encode(&buf, src, &jpeg.Options{Quality: 90})
+		if err != nil {
+			return Image{}, err
+		}
+		img.Ext = ".jpg"
+		first = src
+	case "image/png":
+		src, err := png.Decode(bytes.NewReader(dat))
+		if err != nil {
+			return Image{}, fmt.Errorf("decoding png: %w", err)
+		}
+		if err := png.Encode(&buf, src); err != nil {
+			return Image{}, err
+		}
+		img.Ext = ".png"
+		first = src
+	case "image/gif":
+		// Keep every frame of an animation; only comments and application
+		// extensions are lost.
+		frames, err := gifFrameCount(dat)
+		if err != nil {
+			return Image{}, fmt.Errorf("reading gif: %w", err)
+		}
+		if frames*config.Width*config.Height > MaxPixels {
+			return Image{}, fmt.Errorf("animation must be at most %d pixels across all frames", MaxPixels)
+		}
+		src, err := gif.DecodeAll(bytes.NewReader(dat))
+		if err != nil {
+			return Image{}, fmt.Errorf("decoding gif: %w", err)
+		}
+		if err := gif.EncodeAll(&buf, src); err != nil {
+			return Image{}, err
+		}
+		img.Ext = ".gif"
+		first = src.Image[0]
+	}
+	img.Data = buf.Bytes()
+
+	thumb := thumbnail(first)
+	buf = bytes.Buffer{}
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 80})
+		img.ThumbContentType, img.ThumbExt = "image/jpeg", ".jpg"
+	} else {
+		err = png.Encode(&buf, thumb)
+		img.ThumbContentType, img.ThumbExt = "image/png", ".png"
+	}
+	if err != nil {
+		return Image{}, err
+	}
+	img.Thumb = buf.Bytes()
+	img.ThumbWidth = thumb.Bounds().Dx()
+	img.ThumbHeight = thumb.Bounds().Dy()
+	return img, nil
+}
+
+func thumbnail(src image.Image) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > ThumbSize || h > ThumbSize {
+		if w >= h {
+			w, h = ThumbSize, max(1, h*ThumbSize/w)
+		} else {
+			w, h = max(1, w*ThumbSize/h), ThumbSize
+		}
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
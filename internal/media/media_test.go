@@ -0,0 +1,183 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testImage(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 100, 255})
+		}
+	}
+	return img
+}
+
+// Test PNG processing & thumbnail size
+func TestProcessPNG(t *testing.T) {
+	buf := bytes.Buffer{}
+	png.Encode(&buf, testImage(800, 400))
+
+	img, err := Process(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Failed to process png: %v", err)
+	}
+	if img.ContentType != "image/png" || img.Width != 800 || img.Height != 400 {
+		t.Errorf("Unexpected image %s %dx%d", img.ContentType, img.Width, img.Height)
+	}
+	if img.ThumbWidth != ThumbSize || img.ThumbHeight != ThumbSize/2 {
+		t.Errorf("Expected %dx%d thumbnail, got %dx%d", ThumbSize, ThumbSize/2, img.ThumbWidth, img.ThumbHeight)
+	}
+	thumb, err := png.Decode(bytes.NewReader(img.Thumb))
+	if err != nil {
+		t.Fatalf("Failed to decode thumbnail: %v", err)
+	}
+	if thumb.Bounds().Dx() != img.ThumbWidth {
+		t.Errorf("Thumbnail is %d wide, expected %d", thumb.Bounds().Dx(), img.ThumbWidth)
+	}
+}
+
+// Test EXIF is stripped from JPEGs
+func TestProcessStripsExif(t *testing.T) {
+	buf := bytes.Buffer{}
+	jpeg.Encode(&buf, testImage(40, 30), nil)
+	dat := buf.Bytes()
+	// Splice an APP1 Exif segment in right after the SOI marker.
+	exif := append([]byte{0xFF, 0xE1, 0x00, 0x10}, []byte("Exif\x00\x00GPS-SECRET")...)
+	withExif := append(append(append([]byte{}, dat[:2]...), exif...), dat[2:]...)
+
+	img, err := Process(withExif)
+	if err != nil {
+		t.Fatalf("Failed to process jpeg: %v", err)
+	}
+	if bytes.Contains(img.Data, []byte("Exif")) || bytes.Contains(img.Data, []byte("GPS-SECRET")) {
+		t.Errorf("Expected EXIF to be stripped")
+	}
+	if img.ThumbWidth != 40 || img.ThumbHeight != 30 {
+		t.Errorf("Small images should not be scaled up, got %dx%d", img.ThumbWidth, img.ThumbHeight)
+	}
+}
+
+// Test JPEGs are turned upright by their EXIF Orientation
+func TestProcessOrientation(t *testing.T) {
+	// Left half red, right half blue
+	src := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 30; y++ {
+			c := color.RGBA{255, 0, 0, 255}
+			if x >= 20 {
+				c = color.RGBA{0, 0, 255, 255}
+			}
+			src.Set(x, y, c)
+		}
+	}
+	buf := bytes.Buffer{}
+	jpeg.Encode(&buf, src, &jpeg.Options{Quality: 100})
+	dat := buf.Bytes()
+	// Big-endian TIFF with one IFD0 entry: Orientation = 6 (rotate 90 clockwise)
+	tiff := []byte("MM\x00\x2A\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00\x00\x00\x00\x00")
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	exif := append([]byte{0xFF, 0xE1, 0x00, byte(len(segment) + 2)}, segment...)
+	withExif := append(append(append([]byte{}, dat[:2]...), exif...), dat[2:]...)
+
+	img, err := Process(withExif)
+	if err != nil {
+		t.Fatalf("Failed to process jpeg: %v", err)
+	}
+	if img.Width != 30 || img.Height != 40 || img.ThumbWidth != 30 || img.ThumbHeight != 40 {
+		t.Errorf("Expected 30x40, got %dx%d (thumb %dx%d)", img.Width, img.Height, img.ThumbWidth, img.ThumbHeight)
+	}
+	out, err := jpeg.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	// The left half ends up on top
+	if r, _, b, _ := out.At(15, 5).RGBA(); r < b {
+		t.Errorf("Expected red at the top")
+	}
+	if r, _, b, _ := out.At(15, 35).RGBA(); b < r {
+		t.Errorf("Expected blue at the bottom")
+	}
+}
+
+// Test animations are limited by their pixels across all frames
+func TestProcessGIFFrames(t *testing.T) {
+	animation := func(frames int) []byte {
+		g := &gif.GIF{Config: image.Config{Width: 2000, Height: 2000, ColorModel: color.Palette(palette.Plan9)}}
+		for i := 0; i < frames; i++ {
+			g.Image = append(g.Image, image.NewPaletted(image.Rect(0, 0, 1, 1), palette.Plan9))
+			g.Delay = append(g.Delay, 10)
+		}
+		buf := bytes.Buffer{}
+		if err := gif.EncodeAll(&buf, g); err != nil {
+			t.Fatalf("Failed to encode gif: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	dat := animation(MaxPixels / (2000 * 2000))
+	frames, err := gifFrameCount(dat)
+	if err != nil || frames != MaxPixels/(2000*2000) {
+		t.Errorf("Expected %d frames, got %d (%v)", MaxPixels/(2000*2000), frames, err)
+	}
+	if _, err := Process(dat); err != nil {
+		t.Errorf("Failed to process gif: %v", err)
+	}
+	if _, err := Process(animation(MaxPixels/(2000*2000) + 1)); err == nil {
+		t.Errorf("Expected error for too many frames, but got nil")
+	}
+}
+
+// Test non-images are rejected
+func TestProcessUnsupported(t *testing.T) {
+	_, err := Process([]byte("<html><body>not an image</body></html>"))
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+}
+
+// Test local storage round trip
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir(), "/media/")
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	ctx := context.Background()
+	if err := s.Put(ctx, "a.png", []byte("data"), "image/png"); err != nil {
+		t.Fatalf("Failed to put: %v", err)
+	}
+	if err := s.Put(ctx, "../escape.png", []byte("data"), "image/png"); err == nil {
+		t.Errorf("Expected error for key with path, but got nil")
+	}
+	if url := s.URL("a.png"); url != "/media/a.png" {
+		t.Errorf("Expected /media/a.png but got %v", url)
+	}
+
+	srv := http.StripPrefix("/media", s.Handler())
+	for path, code := range map[string]int{"/media/a.png": 200, "/media/": 404, "/media/missing.png": 404} {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != code {
+			t.Errorf("GET %s: expected %d but got %d", path, code, rec.Code)
+		}
+	}
+
+	if err := s.Delete(ctx, "a.png"); err != nil {
+		t.Errorf("Failed to delete: %v", err)
+	}
+	if err := s.Delete(ctx, "a.png"); err != nil {
+		t.Errorf("Deleting a missing file should not fail: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 100
+)
+
+// Cursor marks the last row a client has seen. It is handed out as an
+// opaque string so the encoding can change without breaking clients.
+// Score is only set for orderings that rank by a count, such as likes.
+type Cursor struct {
+	Score     int64     `json:"s,omitempty"`
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+type Page struct {
+	Limit     int32
+	Cursor    Cursor
+	HasCursor bool
+}
+
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	return EncodeScoreCursor(0, createdAt, id)
+}
+
+func EncodeScoreCursor(score int64, createdAt time.Time, id uuid.UUID) string {
+	dat, err := json.Marshal(Cursor{Score: score, CreatedAt: createdAt, ID: id})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(dat)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	dat, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("malformed cursor: %w", err)
+	}
+	c := Cursor{}
+	if err := json.Unmarshal(dat, &c); err != nil {
+		return Cursor{}, fmt.Errorf("malformed cursor: %w", err)
+	}
+	if c.ID == uuid.Nil || c.CreatedAt.IsZero() {
+		return Cursor{}, fmt.Errorf("incomplete cursor")
+	}
+	return c, nil
+}
+
+// ParseQuery reads the `limit` and `cursor` query parameters.
+func ParseQuery(q url.Values) (Page, error) {
+	page := Page{Limit: DefaultLimit}
+
+	if l := q.Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			return Page{}, fmt.Errorf("limit must be a positive integer")
+		}
+		if limit > MaxLimit {
+			limit = MaxLimit
+		}
+		page.Limit = int32(limit)
+	}
+
+	if c := q.Get("cursor"); c != "" {
+		cursor, err := DecodeCursor(c)
+		if err != nil {
+			return Page{}, err
+		}
+		page.Cursor = cursor
+		page.HasCursor = true
+	}
+	return page, nil
+}
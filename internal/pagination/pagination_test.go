@@ -0,0 +1,69 @@
+package pagination
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Test cursor round trip
+func TestCursorRoundTrip(t *testing.T) {
+	id := uuid.New()
+	created := time.Date(2025, 3, 1, 12, 30, 0, 123456000, time.UTC)
+
+	c, err := DecodeCursor(EncodeCursor(created, id))
+	if err != nil {
+		t.Fatalf("Failed to decode cursor: %v", err)
+	}
+	if c.ID != id || !c.CreatedAt.Equal(created) {
+		t.Errorf("Expected %v/%v but got %v/%v", created, id, c.CreatedAt, c.ID)
+	}
+
+	c, err = DecodeCursor(EncodeScoreCursor(42, created, id))
+	if err != nil {
+		t.Fatalf("Failed to decode score cursor: %v", err)
+	}
+	if c.Score != 42 {
+		t.Errorf("Expected score 42 but got %d", c.Score)
+	}
+
+	_, err = DecodeCursor("not-a-cursor")
+	if err == nil {
+		t.Errorf("Expected malformed cursor error, but got nil")
+	}
+}
+
+// Test limit & cursor query parsing
+func TestParseQuery(t *testing.T) {
+	page, err := ParseQuery(url.Values{})
+	if err != nil {
+		t.Fatalf("Failed to parse empty query: %v", err)
+	}
+	if page.Limit != DefaultLimit || page.HasCursor {
+		t.Errorf("Expected default page, got %+v", page)
+	}
+
+	page, err = ParseQuery(url.Values{"limit": {"1000"}})
+	if err != nil {
+		t.Fatalf("Failed to parse limit: %v", err)
+	}
+	if page.Limit != MaxLimit {
+		t.Errorf("Expected limit to be capped at %d, got %d", MaxLimit, page.Limit)
+	}
+
+	_, err = ParseQuery(url.Values{"limit": {"0"}})
+	if err == nil {
+		t.Errorf("Expected error for zero limit, but got nil")
+	}
+
+	id := uuid.New()
+	page, err = ParseQuery(url.Values{"cursor": {EncodeCursor(time.Now().UTC(), id)}})
+	if err != nil {
+		t.Fatalf("Failed to parse cursor: %v", err)
+	}
+	if !page.HasCursor || page.Cursor.ID != id {
+		t.Errorf("Expected cursor for %v, got %+v", id, page)
+	}
+}
@@ -0,0 +1,180 @@
+package profanity
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+type Mode string
+
+const (
+	// Mask replaces blocked words with Replacement.
+	Mask Mode = "mask"
+	// Reject refuses any text containing a blocked word.
+	Reject Mode = "reject"
+
+	Replacement = "****"
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch Mode(strings.ToLower(s)) {
+	case Mask:
+		return Mask, nil
+	case Reject:
+		return Reject, nil
+	}
+	return "", fmt.Errorf("unknown profanity mode %q", s)
+}
+
+// Result is the outcome of checking one piece of text. Rules lists the
+// blocked words that matched, in the order they first appeared.
+type Result struct {
+	Text     string
+	Rules    []string
+	Rejected bool
+}
+
+// Filter matches whole words against a word list. Words are compared after
+// Unicode case folding and split on anything that is not a letter, digit or
+// mark, so "Kerfuffle!" matches "kerfuffle". It is safe for concurrent use.
+type Filter struct {
+	mu    sync.RWMutex
+	mode  Mode
+	words map[string]struct{}
+}
+
+func New(mode Mode, words ...string) *Filter {
+	f := &Filter{mode: mode, words: make(map[string]struct{})}
+	f.Add(words...)
+	return f
+}
+
+// ReadWords reads a word list with one word per line. Blank lines and lines
+// starting with # are ignored.
+func ReadWords(r io.Reader) ([]string, error) {
+	words := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words, scanner.Err()
+}
+
+// Normalize returns the form a word is stored and matched in, or "" if the
+// word has no letters or digits at all.
+func Normalize(word string) string {
+	b := strings.Builder{}
+	for _, r := range word {
+		if isWordRune(r) {
+			b.WriteRune(fold(r))
+		}
+	}
+	return b.String()
+}
+
+func (f *Filter) Mode() Mode {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.mode
+}
+
+func (f *Filter) Add(words ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, word := range words {
+		if w := Normalize(word); w != "" {
+			f.words[w] = struct{}{}
+		}
+	}
+}
+
+func (f *Filter) Remove(word string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.words, Normalize(word))
+}
+
+// Words returns the word list in sorted order.
+func (f *Filter) Words() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	words := make([]string, 0, len(f.words))
+	for w := range f.words {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+	return words
+}
+
+func (f *Filter) Check(text string) Result {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	res := Result{Text: text, Rules: []string{}}
+	seen := make(map[string]bool)
+	out := strings.Builder{}
+	rest := text
+	for rest != "" {
+		// Copy everything up to the next word through unchanged.
+		start := strings.IndexFunc(rest, isWordRune)
+		if start < 0 {
+			out.WriteString(rest)
+			break
+		}
+		out.WriteString(rest[:start])
+		rest = rest[start:]
+
+		end := strings.IndexFunc(rest, func(r rune) bool { return !isWordRune(r) })
+		if end < 0 {
+			end = len(rest)
+		}
+		word := rest[:end]
+		rest = rest[end:]
+
+		key := Normalize(word)
+		if _, ok := f.words[key]; !ok {
+			out.WriteString(word)
+			continue
+		}
+		out.WriteString(Replacement)
+		if !seen[key] {
+			seen[key] = true
+			res.Rules = append(res.Rules, key)
+		}
+	}
+
+	if len(res.Rules) == 0 {
+		return res
+	}
+	if f.mode == Reject {
+		res.Rejected = true
+		return res
+	}
+	res.Text = out.String()
+	return res
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// fold maps a rune to the lower case of the smallest rune in its case
+// folding orbit, which makes K, k and the Kelvin sign, or σ and ς, equal.
+func fold(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return unicode.ToLower(min)
+}
@@ -0,0 +1,81 @@
+package profanity
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Test masking with punctuation and case
+func TestCheckMask(t *testing.T) {
+	f := New(Mask, "kerfuffle", "sharbert", "fornax")
+
+	cases := []struct {
+		input    string
+		expected string
+		rules    []string
+	}{
+		{"This is a kerfuffle opinion I need to share with the world", "This is a **** opinion I need to share with the world", []string{"kerfuffle"}},
+		{"KERFUFFLE! and Sharbert, again kerfuffle.", "****! and ****, again ****.", []string{"kerfuffle", "sharbert"}},
+		{"nothing to see here", "nothing to see here", []string{}},
+		{"kerfuffles are fine", "kerfuffles are fine", []string{}},
+	}
+	for _, c := range cases {
+		res := f.Check(c.input)
+		if res.Text != c.expected {
+			t.Errorf("Check(%q) text = %q, expected %q", c.input, res.Text, c.expected)
+		}
+		if !reflect.DeepEqual(res.Rules, c.rules) {
+			t.Errorf("Check(%q) rules = %v, expected %v", c.input, res.Rules, c.rules)
+		}
+		if res.Rejected {
+			t.Errorf("Check(%q) rejected in mask mode", c.input)
+		}
+	}
+}
+
+// Test Unicode case folding
+func TestCheckUnicode(t *testing.T) {
+	f := New(Mask, "ΣΟΦΟΣ", "straße")
+
+	res := f.Check("σοφος and STRAẞE")
+	if res.Text != "**** and ****" {
+		t.Errorf("Expected both words masked, got %q", res.Text)
+	}
+	// Kelvin sign folds to k
+	res = New(Mask, "kerfuffle").Check("Kerfuffle")
+	if res.Text != Replacement {
+		t.Errorf("Expected Kelvin sign to match, got %q", res.Text)
+	}
+}
+
+// Test reject mode
+func TestCheckReject(t *testing.T) {
+	f := New(Reject, "fornax")
+
+	res := f.Check("a fornax.")
+	if !res.Rejected || res.Text != "a fornax." || len(res.Rules) != 1 {
+		t.Errorf("Expected rejection with original text, got %+v", res)
+	}
+	if res := f.Check("clean"); res.Rejected {
+		t.Errorf("Expected clean text to pass, got %+v", res)
+	}
+}
+
+// Test word list management
+func TestWords(t *testing.T) {
+	words, err := ReadWords(strings.NewReader("# comment\nFornax\n\n  sharbert  \n"))
+	if err != nil {
+		t.Fatalf("Failed to read words: %v", err)
+	}
+	f := New(Mask, words...)
+	f.Add("Kerfuffle", "!!!")
+	f.Remove("SHARBERT")
+	if got := f.Words(); !reflect.DeepEqual(got, []string{"fornax", "kerfuffle"}) {
+		t.Errorf("Unexpected word list %v", got)
+	}
+
+	if _, err := ParseMode("shout"); err == nil {
+		t.Errorf("Expected error for unknown mode, but got nil")
+	}
+}
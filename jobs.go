@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// runEvery calls job once per interval until ctx is done. Errors are logged
+// and the job simply runs again on the next tick.
+func runEvery(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				log.Printf("%s job failed: %v", name, err)
+			}
+		}
+	}
+}
+
+// purgeDeletedAccounts hard-deletes accounts whose grace period has run
+// out. Their chirps, tokens, likes and follows go with them via ON DELETE CASCADE,
+// except chirps with replies, which first become authorless tombstones so the
+// threads under them stay intact. Tombstones keep nothing else of the chirp.
+func (cfg *apiConfig) purgeDeletedAccounts(ctx context.Context) error {
+	cutoff := time.Now().UTC().Add(-cfg.deletion_grace)
+	// One transaction, with the accounts locked by the first statement, so a
+	// login that cancels the deletion cannot land in between.
+	tx, err := cfg.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := cfg.db.WithTx(tx)
+
+	tombstoned, err := qtx.ChirpsTombstonePurgedUsers(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	if err := qtx.ChirpsStripTombstoned(ctx, tombstoned); err != nil {
+		return err
+	}
+	purged, err := qtx.UsersPurgeDeleted(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("Purged %d deleted accounts, tombstoned %d chirps", purged, len(tombstoned))
+	}
+	return nil
+}
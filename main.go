@@ -2,13 +2,17 @@ package main
 
 import (
 	// "fmt"
+	"context"
 	"database/sql"
 	"log"
 	"net/http"
 	"os"
 	"sync/atomic"
+	"time"
 
 	"github.com/AkuPython/Chirpy/internal/database"
+	"github.com/AkuPython/Chirpy/internal/media"
+	"github.com/AkuPython/Chirpy/internal/profanity"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
@@ -16,11 +20,19 @@ import (
 type apiConfig struct {
 	fileserverHits atomic.Int32
 	db *database.Queries
+	conn *sql.DB
 	platform string
 	jwt_secret string
 	polka_key string
+	admin_key string
+	deletion_grace time.Duration
+	exports *exportStore
+	profanity *profanity.Filter
+	media media.Storage
 }
 
+const defaultDeletionGrace = 30 * 24 * time.Hour
+
 
 func main()  {
 	godotenv.Load(".env")
@@ -28,29 +40,99 @@ func main()  {
 	platform := os.Getenv("PLATFORM")
 	jwt_secret := os.Getenv("JWT_SECRET")
 	polka_key := os.Getenv("POLKA_KEY")
+	admin_key := os.Getenv("ADMIN_KEY")
+	deletion_grace := defaultDeletionGrace
+	if grace := os.Getenv("ACCOUNT_DELETION_GRACE"); grace != "" {
+		parsed, err := time.ParseDuration(grace)
+		if err != nil {
+			log.Fatal("Invalid ACCOUNT_DELETION_GRACE! ", err)
+		}
+		deletion_grace = parsed
+	}
 	db, err := sql.Open("postgres", dbURL)
 	if err != nil {
 		log.Fatal("DB open failed! ", err)
 	}
 	dbQueries := database.New(db)
+
+	profanity_mode := profanity.Mask
+	if mode := os.Getenv("PROFANITY_MODE"); mode != "" {
+		profanity_mode, err = profanity.ParseMode(mode)
+		if err != nil {
+			log.Fatal("Invalid PROFANITY_MODE! ", err)
+		}
+	}
+	profanity_filter, err := loadProfanityFilter(context.Background(), dbQueries, os.Getenv("PROFANITY_WORDS_FILE"), profanity_mode)
+	if profanity_filter == nil {
+		log.Fatal("Profanity word list failed! ", err)
+	}
+	if err != nil {
+		log.Printf("Profanity filter: %v", err)
+	}
 	
 	const port = "8080"
 	const rootPath = "."
+
+	media_dir := os.Getenv("MEDIA_DIR")
+	if media_dir == "" {
+		media_dir = "media"
+	}
+	media_store, err := media.NewLocalStorage(media_dir, "/media")
+	if err != nil {
+		log.Fatal("Media directory failed! ", err)
+	}
 	
 	apiCfg := apiConfig{db: dbQueries,
+		conn: db,
 		platform: platform,
 		jwt_secret: jwt_secret,
-		polka_key: polka_key}
+		polka_key: polka_key,
+		admin_key: admin_key,
+		deletion_grace: deletion_grace,
+		exports: newExportStore(),
+		profanity: profanity_filter,
+		media: media_store}
+
+	go runEvery(context.Background(), "account purge", time.Hour, apiCfg.purgeDeletedAccounts)
+	go runEvery(context.Background(), "export cleanup", time.Hour, apiCfg.exports.expire)
+	go runEvery(context.Background(), "media cleanup", time.Hour, apiCfg.purgeUnattachedMedia)
+	go runEvery(context.Background(), "scheduled chirps", publishInterval, apiCfg.publishDueChirps)
+	go runEvery(context.Background(), "chirp trash purge", time.Hour, apiCfg.purgeDeletedChirps)
 
 
 	mux := http.NewServeMux()
 	fsHandler := http.StripPrefix("/app",http.FileServer(http.Dir(rootPath)))
 	mux.Handle("/app/", apiCfg.middlewareMetricsInc(fsHandler))
 	mux.HandleFunc("GET /api/healthz", handlerReadiness)
+	mux.Handle("GET /media/", http.StripPrefix("/media", media_store.Handler()))
 	
 	mux.HandleFunc("POST /api/users", apiCfg.handlerAddUser)
 	mux.HandleFunc("PUT /api/users", apiCfg.handlerUpdateUser)
+	mux.HandleFunc("PATCH /api/users", apiCfg.handlerPatchUser)
+	mux.HandleFunc("DELETE /api/users/me", apiCfg.handlerDeleteMe)
+	mux.HandleFunc("GET /api/users/me/export", apiCfg.handlerExportMe)
+	mux.HandleFunc("GET /api/users/me/export/{jobId}", apiCfg.handlerGetExportJob)
+	mux.HandleFunc("POST /api/users/me/import", apiCfg.handlerImportMe)
 	mux.HandleFunc("POST /api/login", apiCfg.handlerLogin)
+	mux.HandleFunc("POST /api/users/{userId}/follow", apiCfg.handlerFollowUser)
+	mux.HandleFunc("DELETE /api/users/{userId}/follow", apiCfg.handlerUnfollowUser)
+	mux.HandleFunc("POST /api/users/{userId}/block", apiCfg.handlerBlockUser)
+	mux.HandleFunc("DELETE /api/users/{userId}/block", apiCfg.handlerUnblockUser)
+	mux.HandleFunc("POST /api/users/{userId}/mute", apiCfg.handlerMuteUser)
+	mux.HandleFunc("DELETE /api/users/{userId}/mute", apiCfg.handlerUnmuteUser)
+	mux.HandleFunc("GET /api/users/me/mentions", apiCfg.handlerGetMentions)
+	mux.HandleFunc("GET /api/users/me/scheduled", apiCfg.handlerGetScheduled)
+	mux.HandleFunc("GET /api/users/me/trash", apiCfg.handlerGetTrash)
+	mux.HandleFunc("GET /api/users/me/bookmarks", apiCfg.handlerGetBookmarks)
+	mux.HandleFunc("GET /api/users/me/blocks", apiCfg.handlerGetBlocks)
+	mux.HandleFunc("GET /api/users/me/mutes", apiCfg.handlerGetMutes)
+	mux.HandleFunc("GET /api/users/me/follow-requests", apiCfg.handlerGetFollowRequests)
+	mux.HandleFunc("POST /api/users/me/follow-requests/{userId}/approve", apiCfg.handlerApproveFollowRequest)
+	mux.HandleFunc("POST /api/users/me/follow-requests/{userId}/deny", apiCfg.handlerDenyFollowRequest)
+	mux.HandleFunc("PUT /api/users/me/pin", apiCfg.handlerPinChirp)
+	mux.HandleFunc("DELETE /api/users/me/pin", apiCfg.handlerUnpinChirp)
+	mux.HandleFunc("GET /api/users/{handle}", apiCfg.handlerGetUserProfile)
+	mux.HandleFunc("GET /api/timeline", apiCfg.handlerGetTimeline)
 	
 	mux.HandleFunc("POST /api/polka/webhooks", apiCfg.handlerPolkaWebhook)
 
@@ -58,12 +140,32 @@ func main()  {
 	mux.HandleFunc("POST /api/revoke", apiCfg.handlerRevoke)
 
 	mux.HandleFunc("GET /api/chirps", apiCfg.handlerGetChirps)
+	mux.HandleFunc("GET /api/chirps/search", apiCfg.handlerSearchChirps)
 	mux.HandleFunc("GET /api/chirps/{chirpId}", apiCfg.handlerGetChirp)
 	mux.HandleFunc("POST /api/chirps", apiCfg.handlerAddChirps)
+	mux.HandleFunc("POST /api/media", apiCfg.handlerUploadMedia)
+	mux.HandleFunc("PUT /api/chirps/{chirpId}", apiCfg.handlerUpdateChirp)
 	mux.HandleFunc("DELETE /api/chirps/{chirpId}", apiCfg.handlerDeleteChirps)
+	mux.HandleFunc("GET /api/chirps/{chirpId}/revisions", apiCfg.handlerGetChirpRevisions)
+	mux.HandleFunc("GET /api/chirps/{chirpId}/thread", apiCfg.handlerGetChirpThread)
+	mux.HandleFunc("PUT /api/chirps/{chirpId}/schedule", apiCfg.handlerRescheduleChirp)
+	mux.HandleFunc("DELETE /api/chirps/{chirpId}/schedule", apiCfg.handlerCancelScheduled)
+	mux.HandleFunc("POST /api/chirps/{chirpId}/restore", apiCfg.handlerRestoreChirp)
+	mux.HandleFunc("POST /api/chirps/{chirpId}/poll/votes", apiCfg.handlerVotePoll)
+	mux.HandleFunc("POST /api/chirps/{chirpId}/likes", apiCfg.handlerLikeChirp)
+	mux.HandleFunc("DELETE /api/chirps/{chirpId}/likes", apiCfg.handlerUnlikeChirp)
+	mux.HandleFunc("POST /api/chirps/{chirpId}/bookmark", apiCfg.handlerBookmarkChirp)
+	mux.HandleFunc("DELETE /api/chirps/{chirpId}/bookmark", apiCfg.handlerUnbookmarkChirp)
+	
+	mux.HandleFunc("GET /api/tags/trending", apiCfg.handlerGetTrendingTags)
+	mux.HandleFunc("GET /api/tags/{tag}/chirps", apiCfg.handlerGetTagChirps)
 	
 	mux.HandleFunc("GET /admin/metrics", apiCfg.handlerGetMetrics)
 	mux.HandleFunc("POST /admin/reset", apiCfg.handlerResetMetrics)
+	mux.HandleFunc("GET /admin/profanity", apiCfg.handlerGetProfanity)
+	mux.HandleFunc("POST /admin/profanity/words", apiCfg.handlerAddProfanityWord)
+	mux.HandleFunc("DELETE /admin/profanity/words/{word}", apiCfg.handlerDeleteProfanityWord)
+	mux.HandleFunc("GET /admin/chirps/{chirpId}/filter", apiCfg.handlerGetChirpFilterHits)
 
 
 	srv := &http.Server{
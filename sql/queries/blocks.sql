@@ -0,0 +1,48 @@
+-- name: BlockAdd :exec
+WITH unfollow AS (
+    DELETE FROM follows
+    WHERE (follower_id = sqlc.arg(blocker_id)::uuid AND followee_id = sqlc.arg(blocked_id)::uuid)
+       OR (follower_id = sqlc.arg(blocked_id)::uuid AND followee_id = sqlc.arg(blocker_id)::uuid)
+),
+unrequest AS (
+    DELETE FROM follow_requests
+    WHERE (requester_id = sqlc.arg(blocker_id)::uuid AND target_id = sqlc.arg(blocked_id)::uuid)
+       OR (requester_id = sqlc.arg(blocked_id)::uuid AND target_id = sqlc.arg(blocker_id)::uuid)
+)
+INSERT INTO blocks (blocker_id, blocked_id, created_at)
+VALUES (
+    sqlc.arg(blocker_id)::uuid, sqlc.arg(blocked_id)::uuid, NOW()
+)
+ON CONFLICT (blocker_id, blocked_id) DO NOTHING;
+
+-- name: BlockDelete :exec
+DELETE FROM blocks
+WHERE blocker_id = $1 AND blocked_id = $2;
+
+-- name: BlockExists :one
+SELECT EXISTS (
+    SELECT 1 FROM blocks
+    WHERE (blocker_id = sqlc.arg(user_a)::uuid AND blocked_id = sqlc.arg(user_b)::uuid)
+       OR (blocker_id = sqlc.arg(user_b)::uuid AND blocked_id = sqlc.arg(user_a)::uuid)
+);
+
+-- name: BlocksGet :many
+SELECT * FROM blocks
+WHERE blocker_id = $1
+ORDER BY created_at DESC;
+
+-- name: MuteAdd :exec
+INSERT INTO mutes (muter_id, muted_id, created_at)
+VALUES (
+    $1, $2, NOW()
+)
+ON CONFLICT (muter_id, muted_id) DO NOTHING;
+
+-- name: MuteDelete :exec
+DELETE FROM mutes
+WHERE muter_id = $1 AND muted_id = $2;
+
+-- name: MutesGet :many
+SELECT * FROM mutes
+WHERE muter_id = $1
+ORDER BY created_at DESC;
@@ -0,0 +1,28 @@
+-- name: ChirpBookmarkAdd :exec
+INSERT INTO chirp_bookmarks (chirp_id, user_id, created_at)
+VALUES (
+    $1, $2, NOW()
+)
+ON CONFLICT (chirp_id, user_id) DO NOTHING;
+
+-- name: ChirpBookmarkDelete :exec
+DELETE FROM chirp_bookmarks
+WHERE chirp_id = $1 AND user_id = $2;
+
+-- name: BookmarksGet :many
+SELECT sqlc.embed(chirps), chirp_bookmarks.created_at AS bookmarked_at
+FROM chirp_bookmarks
+JOIN chirps ON chirps.id = chirp_bookmarks.chirp_id
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirp_bookmarks.user_id = sqlc.arg(user_id)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_visible_to(chirps, sqlc.arg(user_id)::uuid)
+  AND (
+    NOT sqlc.arg(has_cursor)::boolean
+    OR (chirp_bookmarks.created_at, chirps.id) < (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid)
+  )
+ORDER BY chirp_bookmarks.created_at DESC, chirps.id DESC
+LIMIT sqlc.arg(row_limit);
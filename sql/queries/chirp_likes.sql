@@ -0,0 +1,22 @@
+-- name: ChirpLikeAdd :exec
+INSERT INTO chirp_likes (chirp_id, user_id, created_at)
+VALUES (
+    $1, $2, NOW()
+)
+ON CONFLICT (chirp_id, user_id) DO NOTHING;
+
+-- name: ChirpLikeDelete :exec
+DELETE FROM chirp_likes
+WHERE chirp_id = $1 AND user_id = $2;
+
+-- name: ChirpLikeCounts :many
+SELECT chirp_id, COUNT(*) AS like_count
+FROM chirp_likes
+WHERE chirp_id = ANY(@chirp_ids::uuid[])
+GROUP BY chirp_id;
+
+-- name: ChirpsLikedBy :many
+SELECT chirp_id
+FROM chirp_likes
+WHERE user_id = @user_id
+  AND chirp_id = ANY(@chirp_ids::uuid[]);
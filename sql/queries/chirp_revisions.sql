@@ -0,0 +1,4 @@
+-- name: ChirpRevisionsGet :many
+SELECT * FROM chirp_revisions
+WHERE chirp_id = $1
+ORDER BY created_at DESC;
@@ -1,19 +1,158 @@
 -- name: ChirpAdd :one
-INSERT INTO chirps (id, created_at, updated_at, body, user_id)
+INSERT INTO chirps (id, created_at, updated_at, body, user_id, parent_id, rechirp_of_id, quoted_chirp_id, publish_at, visibility)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: ChirpImport :one
+INSERT INTO chirps (id, created_at, updated_at, body, user_id)
+SELECT gen_random_uuid(), @created_at::timestamp, @created_at::timestamp, @body::text, @user_id::uuid
+WHERE NOT EXISTS (
+    SELECT 1 FROM chirps
+    WHERE user_id = @user_id::uuid
+      AND created_at = @created_at::timestamp
+      AND body = @body::text
 )
 RETURNING *;
 
 -- name: ChirpGet :one
-SELECT * FROM chirps
-WHERE id = $1 LIMIT 1;
+SELECT chirps.* FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirps.id = sqlc.arg(id)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_visible_to(chirps, sqlc.arg(viewer_id)::uuid)
+LIMIT 1;
+
+-- name: ChirpGetListed :one
+SELECT chirps.* FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirps.id = sqlc.arg(id)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_listed_to(chirps, sqlc.arg(viewer_id)::uuid)
+LIMIT 1;
+
+-- name: ChirpsGetByIDs :many
+SELECT chirps.* FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirps.id = ANY(@ids::uuid[])
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_visible_to(chirps, sqlc.arg(viewer_id)::uuid);
 
 -- name: ChirpsGet :many
-SELECT * FROM chirps
-WHERE (COALESCE($1::uuid, '00000000-0000-0000-0000-000000000000') = '00000000-0000-0000-0000-000000000000' OR user_id = $1)
-ORDER BY created_at ASC;
+SELECT chirps.* FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE (sqlc.arg(author_id)::uuid = '00000000-0000-0000-0000-000000000000' OR chirps.user_id = sqlc.arg(author_id))
+  AND (sqlc.arg(author_id)::uuid = '00000000-0000-0000-0000-000000000000' OR authors.pinned_chirp_id IS DISTINCT FROM chirps.id)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_listed_to(chirps, sqlc.arg(viewer_id)::uuid)
+  AND (
+    NOT sqlc.arg(has_cursor)::boolean
+    OR (sqlc.arg(sort_desc)::boolean AND (chirps.created_at, chirps.id) < (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid))
+    OR (NOT sqlc.arg(sort_desc)::boolean AND (chirps.created_at, chirps.id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid))
+  )
+ORDER BY
+    CASE WHEN sqlc.arg(sort_desc)::boolean THEN chirps.created_at END DESC,
+    CASE WHEN sqlc.arg(sort_desc)::boolean THEN chirps.id END DESC,
+    chirps.created_at ASC,
+    chirps.id ASC
+LIMIT sqlc.arg(row_limit);
 
--- name: ChirpDelete :exec
-DELETE FROM chirps
+-- name: ChirpSoftDelete :exec
+UPDATE chirps
+SET deleted_at = NOW()
 WHERE id = $1;
+
+-- name: ChirpUpdate :one
+WITH revision AS (
+    INSERT INTO chirp_revisions (id, created_at, chirp_id, body)
+    SELECT gen_random_uuid(), NOW(), chirps.id, chirps.body
+    FROM chirps
+    WHERE chirps.id = $1
+)
+UPDATE chirps
+SET updated_at = NOW(),
+    body = $2
+WHERE chirps.id = $1
+RETURNING *;
+
+-- name: ChirpReplyCounts :many
+SELECT chirps.parent_id::uuid AS chirp_id, COUNT(*) AS reply_count
+FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirps.parent_id = ANY(@chirp_ids::uuid[])
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_visible_to(chirps, @viewer_id::uuid)
+GROUP BY chirps.parent_id;
+
+-- name: ChirpThread :many
+WITH RECURSIVE ancestors AS (
+    SELECT chirps.id, chirps.parent_id
+    FROM chirps
+    WHERE chirps.id = sqlc.arg(id)
+    UNION ALL
+    SELECT c.id, c.parent_id
+    FROM chirps c
+    JOIN ancestors a ON c.id = a.parent_id
+), thread AS (
+    SELECT chirps.id, 0::int AS depth
+    FROM chirps
+    WHERE chirps.id = (SELECT ancestors.id FROM ancestors WHERE ancestors.parent_id IS NULL)
+    UNION ALL
+    SELECT c.id, t.depth + 1
+    FROM chirps c
+    JOIN thread t ON c.parent_id = t.id
+)
+SELECT chirps.*, thread.depth FROM thread
+JOIN chirps ON chirps.id = thread.id
+LEFT JOIN users authors ON authors.id = chirps.user_id
+WHERE authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirp_visible_to(chirps, sqlc.arg(viewer_id)::uuid)
+ORDER BY thread.depth, chirps.created_at, chirps.id;
+
+-- name: ChirpsGetByLikes :many
+SELECT sqlc.embed(chirps), COUNT(chirp_likes.user_id) AS like_count
+FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+LEFT JOIN chirp_likes ON chirp_likes.chirp_id = chirps.id
+WHERE (sqlc.arg(author_id)::uuid = '00000000-0000-0000-0000-000000000000' OR chirps.user_id = sqlc.arg(author_id))
+  AND (sqlc.arg(author_id)::uuid = '00000000-0000-0000-0000-000000000000' OR authors.pinned_chirp_id IS DISTINCT FROM chirps.id)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_listed_to(chirps, sqlc.arg(viewer_id)::uuid)
+GROUP BY chirps.id
+HAVING NOT sqlc.arg(has_cursor)::boolean
+    OR (COUNT(chirp_likes.user_id), chirps.created_at, chirps.id) < (sqlc.arg(cursor_likes)::bigint, sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid)
+ORDER BY like_count DESC, chirps.created_at DESC, chirps.id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: UserChirpCount :one
+SELECT COUNT(*) FROM chirps
+WHERE user_id = @user_id
+  AND tombstoned_at IS NULL
+  AND deleted_at IS NULL
+  AND publish_at IS NULL
+  AND chirp_visible_to(chirps, @viewer_id::uuid);
+
+-- name: ChirpsByUser :many
+SELECT * FROM chirps
+WHERE user_id = $1
+ORDER BY created_at ASC, id ASC;
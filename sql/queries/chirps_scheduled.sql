@@ -0,0 +1,36 @@
+-- name: ChirpsScheduledGet :many
+SELECT * FROM chirps
+WHERE user_id = $1
+  AND publish_at IS NOT NULL
+ORDER BY publish_at ASC, id ASC;
+
+-- name: ChirpReschedule :one
+UPDATE chirps
+SET updated_at = NOW(),
+    publish_at = @publish_at::timestamp
+WHERE id = @id
+  AND user_id = @user_id
+  AND publish_at IS NOT NULL
+RETURNING *;
+
+-- name: ChirpCancelScheduled :execrows
+DELETE FROM chirps
+WHERE id = $1
+  AND user_id = $2
+  AND publish_at IS NOT NULL;
+
+-- name: ChirpsPublishDue :one
+WITH published AS (
+    UPDATE chirps
+    SET created_at = NOW(),
+        updated_at = NOW(),
+        publish_at = NULL
+    WHERE publish_at <= @now::timestamp
+    RETURNING chirps.id, chirps.created_at
+), tags AS (
+    UPDATE chirp_tags
+    SET created_at = published.created_at
+    FROM published
+    WHERE chirp_tags.chirp_id = published.id
+)
+SELECT COUNT(*) FROM published;
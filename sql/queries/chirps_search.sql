@@ -0,0 +1,24 @@
+-- name: ChirpsSearch :many
+SELECT sqlc.embed(chirps),
+    ts_rank(chirps.search_vector, tsq)::real AS rank,
+    ts_headline('english', replace(replace(replace(replace(chirps.body, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), tsq, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2')::text AS snippet
+FROM chirps
+JOIN users authors ON authors.id = chirps.user_id
+CROSS JOIN websearch_to_tsquery('english', sqlc.arg(query)::text) AS tsq
+WHERE chirps.search_vector @@ tsq
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_listed_to(chirps, sqlc.arg(viewer_id)::uuid)
+  AND (sqlc.arg(author_id)::uuid = '00000000-0000-0000-0000-000000000000' OR chirps.user_id = sqlc.arg(author_id))
+  AND chirps.tombstoned_at IS NULL
+  AND (
+    sqlc.arg(by_rank)::boolean
+    OR NOT sqlc.arg(has_cursor)::boolean
+    OR (chirps.created_at, chirps.id) < (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid)
+  )
+ORDER BY
+    CASE WHEN sqlc.arg(by_rank)::boolean THEN ts_rank(chirps.search_vector, tsq) END DESC,
+    chirps.created_at DESC,
+    chirps.id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
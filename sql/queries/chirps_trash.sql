@@ -0,0 +1,50 @@
+-- name: ChirpsTrashGet :many
+SELECT * FROM chirps
+WHERE user_id = $1
+  AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, id DESC;
+
+-- name: ChirpRestore :one
+UPDATE chirps
+SET updated_at = NOW(),
+    deleted_at = NULL
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: ChirpsTombstoneDeleted :many
+UPDATE chirps
+SET updated_at = NOW(),
+    body = '',
+    quoted_chirp_id = NULL,
+    tombstoned_at = NOW(),
+    deleted_at = NULL
+WHERE deleted_at < @cutoff::timestamp
+  AND EXISTS (
+    SELECT 1 FROM chirps replies
+    WHERE replies.parent_id = chirps.id
+  )
+RETURNING id;
+
+-- name: ChirpsStripTombstoned :exec
+WITH media AS (
+    UPDATE media_files SET chirp_id = NULL
+    WHERE media_files.chirp_id = ANY(@chirp_ids::uuid[])
+), revisions AS (
+    DELETE FROM chirp_revisions WHERE chirp_revisions.chirp_id = ANY(@chirp_ids::uuid[])
+), chirp_mentions AS (
+    DELETE FROM mentions WHERE mentions.chirp_id = ANY(@chirp_ids::uuid[])
+), tags AS (
+    DELETE FROM chirp_tags WHERE chirp_tags.chirp_id = ANY(@chirp_ids::uuid[])
+), filter_hits AS (
+    DELETE FROM chirp_filter_hits WHERE chirp_filter_hits.chirp_id = ANY(@chirp_ids::uuid[])
+), chirp_polls AS (
+    DELETE FROM polls WHERE polls.chirp_id = ANY(@chirp_ids::uuid[])
+)
+DELETE FROM chirp_likes
+WHERE chirp_likes.chirp_id = ANY(@chirp_ids::uuid[]);
+
+-- name: ChirpsPurgeDeleted :execrows
+DELETE FROM chirps
+WHERE deleted_at < @cutoff::timestamp;
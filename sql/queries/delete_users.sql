@@ -1,2 +1,6 @@
 -- name: DeleteUsers :exec
 DELETE FROM users;
+
+-- name: DeleteAuthorlessChirps :exec
+DELETE FROM chirps
+WHERE user_id IS NULL;
@@ -0,0 +1,46 @@
+-- name: FollowRequestAdd :exec
+INSERT INTO follow_requests (requester_id, target_id, created_at)
+SELECT sqlc.arg(requester_id)::uuid, sqlc.arg(target_id)::uuid, NOW()
+WHERE NOT EXISTS (
+    SELECT 1 FROM blocks
+    WHERE (blocks.blocker_id = sqlc.arg(requester_id)::uuid AND blocks.blocked_id = sqlc.arg(target_id)::uuid)
+       OR (blocks.blocker_id = sqlc.arg(target_id)::uuid AND blocks.blocked_id = sqlc.arg(requester_id)::uuid)
+)
+  AND NOT EXISTS (
+    SELECT 1 FROM follows
+    WHERE follows.follower_id = sqlc.arg(requester_id)::uuid AND follows.followee_id = sqlc.arg(target_id)::uuid
+)
+ON CONFLICT (requester_id, target_id) DO NOTHING;
+
+-- name: FollowRequestDelete :execrows
+DELETE FROM follow_requests
+WHERE requester_id = $1 AND target_id = $2;
+
+-- name: FollowRequestsGet :many
+SELECT follow_requests.*, users.handle FROM follow_requests
+JOIN users ON users.id = follow_requests.requester_id
+WHERE follow_requests.target_id = $1
+  AND users.deletion_requested_at IS NULL
+ORDER BY follow_requests.created_at, follow_requests.requester_id;
+
+-- name: FollowRequestApprove :one
+WITH approved AS (
+    DELETE FROM follow_requests
+    WHERE requester_id = sqlc.arg(requester_id) AND target_id = sqlc.arg(target_id)
+    RETURNING requester_id, target_id
+), followed AS (
+    INSERT INTO follows (follower_id, followee_id, created_at)
+    SELECT requester_id, target_id, NOW() FROM approved
+    ON CONFLICT (follower_id, followee_id) DO NOTHING
+)
+SELECT COUNT(*) FROM approved;
+
+-- name: FollowRequestsApproveAll :exec
+WITH approved AS (
+    DELETE FROM follow_requests
+    WHERE target_id = $1
+    RETURNING requester_id, target_id
+)
+INSERT INTO follows (follower_id, followee_id, created_at)
+SELECT requester_id, target_id, NOW() FROM approved
+ON CONFLICT (follower_id, followee_id) DO NOTHING;
@@ -0,0 +1,35 @@
+-- name: FollowAdd :exec
+INSERT INTO follows (follower_id, followee_id, created_at)
+SELECT sqlc.arg(follower_id)::uuid, sqlc.arg(followee_id)::uuid, NOW()
+WHERE NOT EXISTS (
+    SELECT 1 FROM blocks
+    WHERE (blocks.blocker_id = sqlc.arg(follower_id)::uuid AND blocks.blocked_id = sqlc.arg(followee_id)::uuid)
+       OR (blocks.blocker_id = sqlc.arg(followee_id)::uuid AND blocks.blocked_id = sqlc.arg(follower_id)::uuid)
+)
+ON CONFLICT (follower_id, followee_id) DO NOTHING;
+
+-- name: FollowDelete :exec
+DELETE FROM follows
+WHERE follower_id = $1 AND followee_id = $2;
+
+-- name: UserFollowCounts :one
+SELECT
+    (SELECT COUNT(*) FROM follows WHERE follows.followee_id = @user_id) AS follower_count,
+    (SELECT COUNT(*) FROM follows WHERE follows.follower_id = @user_id) AS following_count;
+
+-- name: TimelineGet :many
+SELECT chirps.* FROM chirps
+JOIN follows ON follows.followee_id = chirps.user_id
+JOIN users authors ON authors.id = chirps.user_id
+WHERE follows.follower_id = sqlc.arg(user_id)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_listed_to(chirps, sqlc.arg(user_id)::uuid)
+  AND (
+    NOT sqlc.arg(has_cursor)::boolean
+    OR (chirps.created_at, chirps.id) < (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid)
+  )
+ORDER BY chirps.created_at DESC, chirps.id DESC
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,28 @@
+-- name: MediaAdd :one
+INSERT INTO media_files (
+    id, created_at, user_id, content_type, width, height, size_bytes,
+    storage_key, thumb_key, thumb_width, thumb_height
+)
+VALUES (
+    $1, NOW(), $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+RETURNING *;
+
+-- name: MediaAttach :execrows
+UPDATE media_files
+SET chirp_id = sqlc.arg(chirp_id)::uuid,
+    position = array_position(sqlc.arg(ids)::uuid[], id)
+WHERE id = ANY(sqlc.arg(ids)::uuid[])
+  AND user_id = sqlc.arg(user_id)::uuid
+  AND chirp_id IS NULL;
+
+-- name: MediaForChirps :many
+SELECT * FROM media_files
+WHERE chirp_id = ANY(@chirp_ids::uuid[])
+ORDER BY chirp_id, position ASC;
+
+-- name: MediaPurgeUnattached :many
+DELETE FROM media_files
+WHERE chirp_id IS NULL
+  AND created_at < @cutoff::timestamp
+RETURNING storage_key, thumb_key;
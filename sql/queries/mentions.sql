@@ -0,0 +1,36 @@
+-- name: MentionsAdd :exec
+INSERT INTO mentions (chirp_id, user_id, created_at)
+SELECT sqlc.arg(chirp_id)::uuid, users.id, NOW()
+FROM users
+WHERE users.handle = ANY(sqlc.arg(handles)::text[])
+  AND NOT EXISTS (
+    SELECT 1 FROM blocks
+    WHERE (blocks.blocker_id = users.id AND blocks.blocked_id = sqlc.arg(author_id)::uuid)
+       OR (blocks.blocker_id = sqlc.arg(author_id)::uuid AND blocks.blocked_id = users.id)
+  )
+ON CONFLICT (chirp_id, user_id) DO NOTHING;
+
+-- name: MentionsClear :exec
+DELETE FROM mentions
+WHERE chirp_id = $1;
+
+-- name: MentionsGet :many
+SELECT chirps.* FROM chirps
+JOIN mentions ON mentions.chirp_id = chirps.id
+JOIN users authors ON authors.id = chirps.user_id
+WHERE mentions.user_id = sqlc.arg(user_id)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_visible_to(chirps, sqlc.arg(user_id)::uuid)
+  AND NOT EXISTS (
+    SELECT 1 FROM mutes
+    WHERE mutes.muter_id = sqlc.arg(user_id)::uuid AND mutes.muted_id = chirps.user_id
+  )
+  AND (
+    NOT sqlc.arg(has_cursor)::boolean
+    OR (chirps.created_at, chirps.id) < (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid)
+  )
+ORDER BY chirps.created_at DESC, chirps.id DESC
+LIMIT sqlc.arg(row_limit);
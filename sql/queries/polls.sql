@@ -0,0 +1,40 @@
+-- name: PollAdd :exec
+WITH poll AS (
+    INSERT INTO polls (chirp_id, created_at, closes_at)
+    VALUES (sqlc.arg(chirp_id)::uuid, NOW(), sqlc.arg(closes_at)::timestamp)
+    RETURNING chirp_id
+)
+INSERT INTO poll_options (id, chirp_id, position, label)
+SELECT gen_random_uuid(), poll.chirp_id, o.position, o.label
+FROM poll, unnest(sqlc.arg(labels)::text[]) WITH ORDINALITY AS o(label, position);
+
+-- name: PollClosesAt :one
+SELECT polls.closes_at FROM polls
+JOIN chirps ON chirps.id = polls.chirp_id
+WHERE polls.chirp_id = @chirp_id
+  AND chirps.user_id = sqlc.arg(user_id)::uuid;
+
+-- name: PollsForChirps :many
+SELECT polls.chirp_id, polls.closes_at, poll_options.id AS option_id, poll_options.label,
+    COUNT(poll_votes.user_id) AS vote_count
+FROM polls
+JOIN poll_options ON poll_options.chirp_id = polls.chirp_id
+LEFT JOIN poll_votes ON poll_votes.option_id = poll_options.id
+WHERE polls.chirp_id = ANY(@chirp_ids::uuid[])
+GROUP BY polls.chirp_id, poll_options.id
+ORDER BY polls.chirp_id, poll_options.position ASC;
+
+-- name: PollVotesBy :many
+SELECT chirp_id, option_id FROM poll_votes
+WHERE user_id = @user_id
+  AND chirp_id = ANY(@chirp_ids::uuid[]);
+
+-- name: PollVoteAdd :execrows
+INSERT INTO poll_votes (chirp_id, user_id, option_id, created_at)
+SELECT poll_options.chirp_id, @user_id, poll_options.id, NOW()
+FROM poll_options
+JOIN polls ON polls.chirp_id = poll_options.chirp_id
+WHERE poll_options.id = @option_id
+  AND poll_options.chirp_id = @chirp_id
+  AND polls.closes_at > NOW()
+ON CONFLICT (chirp_id, user_id) DO NOTHING;
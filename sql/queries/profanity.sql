@@ -0,0 +1,26 @@
+-- name: ProfanityWordsGet :many
+SELECT word FROM profanity_words
+ORDER BY word ASC;
+
+-- name: ProfanityWordAdd :exec
+INSERT INTO profanity_words (word, created_at)
+VALUES ($1, NOW())
+ON CONFLICT (word) DO NOTHING;
+
+-- name: ProfanityWordDelete :execrows
+DELETE FROM profanity_words
+WHERE word = $1;
+
+-- name: ChirpFilterHitsAdd :exec
+INSERT INTO chirp_filter_hits (chirp_id, rule, created_at)
+SELECT sqlc.arg(chirp_id)::uuid, unnest(sqlc.arg(rules)::text[]), NOW()
+ON CONFLICT (chirp_id, rule) DO NOTHING;
+
+-- name: ChirpFilterHitsClear :exec
+DELETE FROM chirp_filter_hits
+WHERE chirp_id = $1;
+
+-- name: ChirpFilterHitsGet :many
+SELECT * FROM chirp_filter_hits
+WHERE chirp_id = $1
+ORDER BY created_at ASC, rule ASC;
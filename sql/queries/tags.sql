@@ -0,0 +1,53 @@
+-- name: ChirpTagsAdd :exec
+-- The no-op update makes the insert return existing tags too, including one
+-- another transaction is creating at the same moment.
+WITH t AS (
+    INSERT INTO tags (id, created_at, name)
+    SELECT gen_random_uuid(), NOW(), names.name
+    FROM (SELECT DISTINCT unnest(sqlc.arg(names)::text[]) AS name) AS names
+    ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+    RETURNING id
+)
+INSERT INTO chirp_tags (chirp_id, tag_id, created_at)
+SELECT sqlc.arg(chirp_id)::uuid, t.id, sqlc.arg(created_at)::timestamp
+FROM t
+ON CONFLICT (chirp_id, tag_id) DO NOTHING;
+
+-- name: ChirpTagsClear :exec
+DELETE FROM chirp_tags
+WHERE chirp_id = $1;
+
+-- name: TagChirpsGet :many
+SELECT chirps.* FROM chirps
+JOIN chirp_tags ON chirp_tags.chirp_id = chirps.id
+JOIN tags ON tags.id = chirp_tags.tag_id
+JOIN users authors ON authors.id = chirps.user_id
+WHERE tags.name = sqlc.arg(name)
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirp_listed_to(chirps, sqlc.arg(viewer_id)::uuid)
+  AND (
+    NOT sqlc.arg(has_cursor)::boolean
+    OR (chirps.created_at, chirps.id) < (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::uuid)
+  )
+ORDER BY chirps.created_at DESC, chirps.id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: TagsTrending :many
+SELECT tags.name, COUNT(*) AS use_count
+FROM chirp_tags
+JOIN tags ON tags.id = chirp_tags.tag_id
+JOIN chirps ON chirps.id = chirp_tags.chirp_id
+JOIN users authors ON authors.id = chirps.user_id
+WHERE chirp_tags.created_at >= sqlc.arg(since)::timestamp
+  AND chirps.tombstoned_at IS NULL
+  AND authors.deletion_requested_at IS NULL
+  AND chirps.publish_at IS NULL
+  AND chirps.deleted_at IS NULL
+  AND chirps.visibility = 'public'
+  AND NOT authors.is_private
+GROUP BY tags.name
+ORDER BY use_count DESC, tags.name ASC
+LIMIT sqlc.arg(row_limit);
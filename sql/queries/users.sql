@@ -1,9 +1,9 @@
 -- name: CreateUser :one
-INSERT INTO users (id, created_at, updated_at, email, hashed_password)
+INSERT INTO users (id, created_at, updated_at, email, hashed_password, handle)
 VALUES (
-    gen_random_uuid(), NOW(), NOW(), $1, $2
+    gen_random_uuid(), NOW(), NOW(), $1, $2, $3
 )
-RETURNING id, created_at, updated_at, email, is_chirpy_red;
+RETURNING id, created_at, updated_at, email, is_chirpy_red, handle;
 
 -- name: GetUserByEmail :one
 SELECT * FROM users
@@ -12,9 +12,13 @@ WHERE email = $1;
 -- name: UpdateOneUser :one
 UPDATE users
 SET updated_at = NOW(),
-    email = $2,
-    hashed_password = $3
-WHERE id = $1
+    email = sqlc.arg(email),
+    hashed_password = sqlc.arg(hashed_password),
+    handle = COALESCE(sqlc.narg(handle)::text, handle),
+    display_name = COALESCE(sqlc.narg(display_name)::text, display_name),
+    bio = COALESCE(sqlc.narg(bio)::text, bio),
+    avatar_url = COALESCE(sqlc.narg(avatar_url)::text, avatar_url)
+WHERE id = sqlc.arg(id)
 RETURNING *;
 
 -- name: UpdateUserRed :one
@@ -22,3 +26,67 @@ UPDATE users
 SET is_chirpy_red = TRUE
 WHERE id = $1
 RETURNING *;
+
+-- name: GetUserByID :one
+SELECT * FROM users
+WHERE id = $1;
+
+-- name: GetUserByHandle :one
+SELECT * FROM users
+WHERE handle = @handle::text
+  AND deletion_requested_at IS NULL;
+
+-- name: PatchUser :one
+UPDATE users
+SET updated_at = NOW(),
+    email = COALESCE(sqlc.narg(email)::text, email),
+    hashed_password = COALESCE(sqlc.narg(hashed_password)::text, hashed_password),
+    handle = COALESCE(sqlc.narg(handle)::text, handle),
+    display_name = COALESCE(sqlc.narg(display_name)::text, display_name),
+    bio = COALESCE(sqlc.narg(bio)::text, bio),
+    avatar_url = COALESCE(sqlc.narg(avatar_url)::text, avatar_url),
+    is_private = COALESCE(sqlc.narg(is_private)::boolean, is_private)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UserRequestDeletion :one
+UPDATE users
+SET updated_at = NOW(),
+    deletion_requested_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: UserCancelDeletion :exec
+UPDATE users
+SET updated_at = NOW(),
+    deletion_requested_at = NULL
+WHERE id = $1;
+
+-- name: ChirpsTombstonePurgedUsers :many
+UPDATE chirps
+SET updated_at = NOW(),
+    body = '',
+    quoted_chirp_id = NULL,
+    tombstoned_at = COALESCE(tombstoned_at, NOW()),
+    deleted_at = NULL,
+    user_id = NULL
+WHERE user_id IN (
+    SELECT users.id FROM users
+    WHERE users.deletion_requested_at < sqlc.arg(cutoff)::timestamp
+    FOR UPDATE
+)
+  AND EXISTS (
+    SELECT 1 FROM chirps replies
+    WHERE replies.parent_id = chirps.id
+  )
+RETURNING id;
+
+-- name: UsersPurgeDeleted :execrows
+DELETE FROM users
+WHERE deletion_requested_at < sqlc.arg(cutoff)::timestamp;
+
+-- name: UserSetPinnedChirp :exec
+UPDATE users
+SET updated_at = NOW(),
+    pinned_chirp_id = $2
+WHERE id = $1;
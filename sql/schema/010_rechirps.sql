@@ -0,0 +1,13 @@
+-- +goose Up
+-- No foreign keys: a rechirp or quote outlives the chirp it points at and
+-- the API reports the reference as unavailable instead.
+ALTER TABLE chirps
+ADD COLUMN rechirp_of_id UUID,
+ADD COLUMN quoted_chirp_id UUID;
+CREATE UNIQUE INDEX chirps_user_id_rechirp_of_id_idx ON chirps (user_id, rechirp_of_id) WHERE rechirp_of_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX chirps_user_id_rechirp_of_id_idx;
+ALTER TABLE chirps
+DROP COLUMN quoted_chirp_id,
+DROP COLUMN rechirp_of_id;
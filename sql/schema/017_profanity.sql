@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE profanity_words (
+    word TEXT PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE chirp_filter_hits (
+    chirp_id UUID NOT NULL REFERENCES chirps(id) ON DELETE CASCADE,
+    rule TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (chirp_id, rule)
+);
+
+-- +goose Down
+DROP TABLE chirp_filter_hits;
+DROP TABLE profanity_words;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Files outlive their rows only briefly: rows with no chirp are purged along
+-- with their files, so both foreign keys set NULL rather than cascade.
+CREATE TABLE media_files (
+    id UUID PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    chirp_id UUID REFERENCES chirps(id) ON DELETE SET NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    content_type TEXT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    storage_key TEXT NOT NULL,
+    thumb_key TEXT NOT NULL,
+    thumb_width INTEGER NOT NULL,
+    thumb_height INTEGER NOT NULL
+);
+CREATE INDEX media_files_chirp_id_idx ON media_files (chirp_id, position);
+CREATE INDEX media_files_unattached_idx ON media_files (created_at) WHERE chirp_id IS NULL;
+
+-- +goose Down
+DROP TABLE media_files;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE chirp_bookmarks (
+    chirp_id UUID NOT NULL REFERENCES chirps(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (chirp_id, user_id)
+);
+CREATE INDEX chirp_bookmarks_user_id_created_at_idx ON chirp_bookmarks (user_id, created_at);
+
+-- +goose Down
+DROP TABLE chirp_bookmarks;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Unlisted chirps can be fetched by id but stay out of listings; direct
+-- chirps are only for the users they mention.
+CREATE TYPE chirp_visibility AS ENUM ('public', 'unlisted', 'followers', 'direct');
+
+ALTER TABLE chirps
+ADD COLUMN visibility chirp_visibility NOT NULL DEFAULT 'public';
+
+-- +goose Down
+ALTER TABLE chirps
+DROP COLUMN visibility;
+
+DROP TYPE chirp_visibility;
@@ -0,0 +1,54 @@
+-- +goose Up
+-- chirp_visible_to is the one place that decides whether viewer may see
+-- chirp: nobody sees across a block, private authors are limited to their
+-- followers, and the chirp's own visibility applies on top. Authors always
+-- see their own chirps. chirp_listed_to also keeps unlisted chirps and muted
+-- authors out of listings.
+-- +goose StatementBegin
+CREATE FUNCTION chirp_visible_to(chirp chirps, viewer UUID) RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+    SELECT chirp.user_id = viewer OR (
+        NOT EXISTS (
+            SELECT 1 FROM blocks
+            WHERE (blocks.blocker_id = chirp.user_id AND blocks.blocked_id = viewer)
+               OR (blocks.blocker_id = viewer AND blocks.blocked_id = chirp.user_id)
+        )
+        AND NOT EXISTS (
+            SELECT 1 FROM users authors
+            WHERE authors.id = chirp.user_id
+              AND authors.is_private
+              AND NOT EXISTS (
+                  SELECT 1 FROM follows
+                  WHERE follows.follower_id = viewer AND follows.followee_id = authors.id
+              )
+        )
+        AND (
+            chirp.visibility IN ('public', 'unlisted')
+            OR (chirp.visibility = 'followers' AND EXISTS (
+                SELECT 1 FROM follows
+                WHERE follows.follower_id = viewer AND follows.followee_id = chirp.user_id
+            ))
+            OR (chirp.visibility = 'direct' AND EXISTS (
+                SELECT 1 FROM mentions
+                WHERE mentions.chirp_id = chirp.id AND mentions.user_id = viewer
+            ))
+        )
+    )
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE FUNCTION chirp_listed_to(chirp chirps, viewer UUID) RETURNS BOOLEAN
+LANGUAGE sql STABLE AS $$
+    SELECT chirp_visible_to(chirp, viewer)
+        AND (chirp.visibility <> 'unlisted' OR chirp.user_id = viewer)
+        AND NOT EXISTS (
+            SELECT 1 FROM mutes
+            WHERE mutes.muter_id = viewer AND mutes.muted_id = chirp.user_id
+        )
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION chirp_listed_to(chirps, UUID);
+DROP FUNCTION chirp_visible_to(chirps, UUID);
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Purging an account keeps tombstones for its chirps that have replies, so
+-- those tombstones outlive their author.
+ALTER TABLE chirps
+ALTER COLUMN user_id DROP NOT NULL;
+
+-- +goose Down
+DELETE FROM chirps
+WHERE user_id IS NULL;
+
+ALTER TABLE chirps
+ALTER COLUMN user_id SET NOT NULL;
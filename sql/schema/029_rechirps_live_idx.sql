@@ -0,0 +1,10 @@
+-- +goose Up
+-- Only live rechirps count, so one in the trash or left as a tombstone does
+-- not stop the same user from rechirping again.
+DROP INDEX chirps_user_id_rechirp_of_id_idx;
+CREATE UNIQUE INDEX chirps_user_id_rechirp_of_id_idx ON chirps (user_id, rechirp_of_id)
+WHERE rechirp_of_id IS NOT NULL AND deleted_at IS NULL AND tombstoned_at IS NULL;
+
+-- +goose Down
+DROP INDEX chirps_user_id_rechirp_of_id_idx;
+CREATE UNIQUE INDEX chirps_user_id_rechirp_of_id_idx ON chirps (user_id, rechirp_of_id) WHERE rechirp_of_id IS NOT NULL;